@@ -1,5 +1,28 @@
+-- 이전 버전에서 만든 seats 테이블은 서버 기동 시 migrateSeatsTable (ticketing-be/migrate.go) 이 없는 컬럼과 인덱스를 추가한다
 CREATE TABLE IF NOT EXISTS seats (
     seat_id INT PRIMARY KEY,
     status VARCHAR(20) NOT NULL DEFAULT 'available',
-    user_id INT
+    user_id INT,
+    reserved_at DATETIME,
+    seat_row INT,
+    seat_col INT,
+    label VARCHAR(16),
+    section VARCHAR(32) NOT NULL DEFAULT 'general',
+    nonce VARCHAR(64) UNIQUE,
+    reservation_seq BIGINT,
+    price INT NOT NULL DEFAULT 0,
+    operator_id INT,
+    confirmation_code CHAR(9) UNIQUE,
+    INDEX idx_user_status (user_id, status)
 );
+
+CREATE TABLE IF NOT EXISTS reservation_sequence (
+    seq BIGINT AUTO_INCREMENT PRIMARY KEY,
+    seat_id INT NOT NULL,
+    user_id INT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS reservation_cap (
+    id TINYINT PRIMARY KEY
+);
+INSERT IGNORE INTO reservation_cap (id) VALUES (1);
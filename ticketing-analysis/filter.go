@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"ticketing-analysis/logs"
+)
+
+// 쉼표로 구분한 목록 (빈 문자열이면 nil)
+func splitList(s string) []string {
+	var out []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// 조건에 맞는 로그 줄만 NDJSON 으로 출력 (큰 로그에서 특정 사용자나 충돌만 뽑아 보기용)
+// log 패키지 날짜 접두어는 떼고 JSON 부분만 원문 그대로 쓴다
+func runFilter(args []string) {
+	fs := flag.NewFlagSet("filter", flag.ExitOnError)
+	actions := fs.String("action", "", "comma-separated actions to keep; glob patterns allowed (e.g. reserve,reserve_*)")
+	levels := fs.String("level", "", "comma-separated levels to keep (e.g. WARN,ERROR)")
+	statuses := fs.String("status", "", "comma-separated statuses to keep (e.g. seat_conflict)")
+	userID := fs.Int("user", 0, "keep only this user_id")
+	seatID := fs.Int("seat", 0, "keep only this seat_id")
+	since := fs.String("since", "", "keep entries at or after this time (same formats as the timestamp field)")
+	until := fs.String("until", "", "keep entries before this time")
+	layout := fs.String("layout", "", "Go time layout of the timestamp field and -since/-until (default: RFC3339 or unix milliseconds)")
+	out := fs.String("o", "-", "output NDJSON path")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: analysis filter [-action A,B] [-level L] [-status S] [-user ID] [-seat ID] [-since T] [-until T] [-o out.ndjson] <ticketing.log>")
+		os.Exit(2)
+	}
+
+	f := logs.Filter{
+		Actions:  splitList(*actions),
+		Levels:   splitList(*levels),
+		Statuses: splitList(*statuses),
+		UserID:   *userID,
+		SeatID:   *seatID,
+		Layout:   *layout,
+	}
+	for _, p := range f.Actions {
+		if _, err := path.Match(p, ""); err != nil {
+			fmt.Fprintf(os.Stderr, "filter: bad -action pattern %q\n", p)
+			os.Exit(2)
+		}
+	}
+	for _, b := range []struct {
+		flag string
+		s    string
+		t    *time.Time
+	}{{"since", *since, &f.Since}, {"until", *until, &f.Until}} {
+		if b.s == "" {
+			continue
+		}
+		t, err := logs.ParseTime(b.s, *layout)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "filter: -%s: %v\n", b.flag, err)
+			os.Exit(2)
+		}
+		*b.t = t
+	}
+
+	dst := os.Stdout
+	if *out != "-" {
+		var err error
+		dst, err = os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "filter: %v\n", err)
+			os.Exit(1)
+		}
+		defer dst.Close()
+	}
+	w := bufio.NewWriter(dst)
+
+	kept := 0
+	st, err := logs.ReadFileRaw(fs.Arg(0), func(e logs.Entry, raw []byte) {
+		if !f.Match(e) {
+			return
+		}
+		kept++
+		w.Write(raw)
+		w.WriteByte('\n')
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "filter: %v\n", err)
+		os.Exit(1)
+	}
+	if err := w.Flush(); err != nil {
+		fmt.Fprintf(os.Stderr, "filter: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Fprintf(os.Stderr, "filter: kept %d of %d lines (skipped %d non-JSON)\n", kept, st.Lines, st.Skipped)
+}
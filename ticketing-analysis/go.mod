@@ -0,0 +1,3 @@
+module ticketing-analysis
+
+go 1.24.2
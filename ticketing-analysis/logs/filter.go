@@ -0,0 +1,50 @@
+package logs
+
+import (
+	"path"
+	"slices"
+	"time"
+)
+
+// 로그 항목 조건 (비어 있는 조건은 검사하지 않음)
+type Filter struct {
+	Actions  []string // action 패턴 중 하나와 일치 (path.Match 형식, 예: reserve_*)
+	Levels   []string
+	Statuses []string
+	UserID   int
+	SeatID   int
+	Since    time.Time // 이 시각 이후 (포함)
+	Until    time.Time // 이 시각 이전 (제외)
+	Layout   string    // timestamp 형식 (ParseTime 참고)
+}
+
+// e 가 모든 조건을 만족하는지
+// 시간 조건이 있을 때 timestamp 를 해석할 수 없는 항목은 제외한다
+func (f Filter) Match(e Entry) bool {
+	if len(f.Actions) > 0 && !slices.ContainsFunc(f.Actions, func(p string) bool {
+		ok, _ := path.Match(p, e.Action)
+		return ok
+	}) {
+		return false
+	}
+	if len(f.Levels) > 0 && !slices.Contains(f.Levels, e.Level) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.Status) {
+		return false
+	}
+	if f.UserID != 0 && e.UserID != f.UserID {
+		return false
+	}
+	if f.SeatID != 0 && e.SeatID != f.SeatID {
+		return false
+	}
+	if f.Since.IsZero() && f.Until.IsZero() {
+		return true
+	}
+	t, err := ParseTime(e.Timestamp, f.Layout)
+	if err != nil {
+		return false
+	}
+	return (f.Since.IsZero() || !t.Before(f.Since)) && (f.Until.IsZero() || t.Before(f.Until))
+}
@@ -0,0 +1,92 @@
+// ticketing-be 가 logJSON 으로 남긴 NDJSON 로그 파싱
+package logs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"time"
+)
+
+// 로그 한 줄 (ticketing-be 의 LogEntry 와 같은 형식)
+type Entry struct {
+	Timestamp string `json:"timestamp"`
+	Level     string `json:"level"`
+	Action    string `json:"action"`
+	UserID    int    `json:"user_id,omitempty"`
+	SeatID    int    `json:"seat_id,omitempty"`
+	Status    string `json:"status,omitempty"`
+	Error     string `json:"error,omitempty"`
+}
+
+// 읽기 결과 (JSON 이 아닌 줄은 건너뛰고 개수만 센다)
+type Stats struct {
+	Lines   int
+	Skipped int
+}
+
+// r 의 각 로그 줄을 fn 에 넘긴다
+// log 패키지의 날짜 접두어 ("2025/06/20 12:00:00 {...}") 가 있어도 첫 '{' 부터 파싱한다
+func Read(r io.Reader, fn func(Entry)) (Stats, error) {
+	return ReadRaw(r, func(e Entry, _ []byte) { fn(e) })
+}
+
+// Read 와 같되 줄의 JSON 부분 (접두어 제외) 도 함께 넘긴다
+// raw 는 다음 줄을 읽으면 덮어쓰이므로 fn 안에서만 쓴다
+func ReadRaw(r io.Reader, fn func(e Entry, raw []byte)) (Stats, error) {
+	var st Stats
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := sc.Bytes()
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		st.Lines++
+
+		i := bytes.IndexByte(line, '{')
+		var e Entry
+		if i < 0 || json.Unmarshal(line[i:], &e) != nil {
+			st.Skipped++
+			continue
+		}
+		fn(e, line[i:])
+	}
+	return st, sc.Err()
+}
+
+// 파일 경로로 읽기 ("-" 이면 표준 입력)
+func ReadFile(path string, fn func(Entry)) (Stats, error) {
+	return ReadFileRaw(path, func(e Entry, _ []byte) { fn(e) })
+}
+
+func ReadFileRaw(path string, fn func(e Entry, raw []byte)) (Stats, error) {
+	if path == "-" {
+		return ReadRaw(os.Stdin, fn)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return Stats{}, err
+	}
+	defer f.Close()
+	return ReadRaw(f, fn)
+}
+
+// timestamp 필드 파싱
+// layout 이 비어 있으면 서버의 LOG_TIME_FORMAT 기본 형식들 (rfc3339, rfc3339nano, unixms) 을 차례로 시도한다
+func ParseTime(s, layout string) (time.Time, error) {
+	if layout != "" {
+		return time.Parse(layout, s)
+	}
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	if ms, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return time.UnixMilli(ms), nil
+	}
+	return time.Time{}, fmt.Errorf("unrecognized timestamp %q", s)
+}
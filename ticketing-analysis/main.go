@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+const usage = `usage: analysis <command> [flags] <ticketing.log>
+
+commands:
+  summary   count log entries by action and status
+  timeline  CSV of reserve success/conflict/error counts per time window
+  users     per-user attempts and wait until first success
+  filter    keep only matching entries and print them as NDJSON
+
+Pass "-" as the file to read from standard input.
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd, args := os.Args[1], os.Args[2:]
+	switch cmd {
+	case "summary":
+		runSummary(args)
+	case "timeline":
+		runTimeline(args)
+	case "users":
+		runUsers(args)
+	case "filter":
+		runFilter(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+
+	"ticketing-analysis/logs"
+)
+
+// action, status 별 로그 건수 집계
+func runSummary(args []string) {
+	fs := flag.NewFlagSet("summary", flag.ExitOnError)
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: analysis summary <ticketing.log>")
+		os.Exit(2)
+	}
+
+	byAction := make(map[string]map[string]int)
+	st, err := logs.ReadFile(fs.Arg(0), func(e logs.Entry) {
+		if byAction[e.Action] == nil {
+			byAction[e.Action] = make(map[string]int)
+		}
+		byAction[e.Action][e.Status]++
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "summary: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Lines: %d (skipped %d non-JSON)\n", st.Lines, st.Skipped)
+	for _, action := range sortedByCount(byAction) {
+		statuses := byAction[action]
+		fmt.Printf("\n%s (%d)\n", action, sum(statuses))
+		for _, kv := range sortedCounts(statuses) {
+			status := kv.key
+			if status == "" {
+				status = "-"
+			}
+			fmt.Printf("  %-28s %d\n", status, kv.n)
+		}
+	}
+}
+
+type keyCount struct {
+	key string
+	n   int
+}
+
+func sum(m map[string]int) int {
+	total := 0
+	for _, n := range m {
+		total += n
+	}
+	return total
+}
+
+// 건수 내림차순 (같으면 이름순)
+func sortedCounts(m map[string]int) []keyCount {
+	out := make([]keyCount, 0, len(m))
+	for k, n := range m {
+		out = append(out, keyCount{k, n})
+	}
+	slices.SortFunc(out, func(a, b keyCount) int {
+		return cmp.Or(cmp.Compare(b.n, a.n), cmp.Compare(a.key, b.key))
+	})
+	return out
+}
+
+func sortedByCount(m map[string]map[string]int) []string {
+	totals := make(map[string]int, len(m))
+	for k, v := range m {
+		totals[k] = sum(v)
+	}
+	keys := make([]string, 0, len(m))
+	for _, kv := range sortedCounts(totals) {
+		keys = append(keys, kv.key)
+	}
+	return keys
+}
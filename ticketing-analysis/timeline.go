@@ -0,0 +1,145 @@
+package main
+
+import (
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ticketing-analysis/logs"
+)
+
+// 예매 결과 분류
+type outcome int
+
+const (
+	outcomeNone outcome = iota // 예매 결과가 아닌 로그
+	outcomeSuccess
+	outcomeConflict
+	outcomeError
+)
+
+// 충돌로 보는 status (좌석이 이미 팔렸거나 잠겨 있음)
+var conflictStatuses = map[string]bool{
+	"seat_conflict":     true,
+	"lock_wait_timeout": true,
+	"seat_disabled":     true,
+	"sold_out":          true,
+	"no_block":          true,
+}
+
+// reserve 계열 action 의 로그 한 줄을 결과로 분류
+func classify(e logs.Entry) outcome {
+	if e.Action != "reserve" && !strings.HasPrefix(e.Action, "reserve_") {
+		return outcomeNone
+	}
+	switch {
+	case e.Status == "success" || e.Status == "replayed" || strings.HasPrefix(e.Status, "success="):
+		return outcomeSuccess
+	case conflictStatuses[e.Status]:
+		return outcomeConflict
+	case e.Level == "ERROR":
+		return outcomeError
+	}
+	return outcomeNone
+}
+
+type bucket struct {
+	success, conflict, errors int
+}
+
+// 시간 구간별 성공/충돌/오류 건수를 CSV 로 출력
+// 매진이 가까워질수록 경합이 어떻게 변하는지 보기 위한 시계열
+func runTimeline(args []string) {
+	fs := flag.NewFlagSet("timeline", flag.ExitOnError)
+	window := fs.Duration("window", time.Second, "bucket width")
+	layout := fs.String("layout", "", "Go time layout of the timestamp field (default: RFC3339 or unix milliseconds)")
+	out := fs.String("o", "-", "output CSV path")
+	fs.Parse(args)
+	if fs.NArg() != 1 || *window <= 0 {
+		fmt.Fprintln(os.Stderr, "usage: analysis timeline [-window 1s] [-layout LAYOUT] [-o out.csv] <ticketing.log>")
+		os.Exit(2)
+	}
+
+	buckets := make(map[int64]*bucket)
+	var first, last int64
+	badTime := 0
+	_, err := logs.ReadFile(fs.Arg(0), func(e logs.Entry) {
+		o := classify(e)
+		if o == outcomeNone {
+			return
+		}
+		t, err := logs.ParseTime(e.Timestamp, *layout)
+		if err != nil {
+			badTime++
+			return
+		}
+
+		k := t.UnixNano() / int64(*window)
+		b := buckets[k]
+		if b == nil {
+			b = &bucket{}
+			buckets[k] = b
+			if len(buckets) == 1 || k < first {
+				first = k
+			}
+			if len(buckets) == 1 || k > last {
+				last = k
+			}
+		}
+		switch o {
+		case outcomeSuccess:
+			b.success++
+		case outcomeConflict:
+			b.conflict++
+		case outcomeError:
+			b.errors++
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "timeline: %v\n", err)
+		os.Exit(1)
+	}
+	if badTime > 0 {
+		fmt.Fprintf(os.Stderr, "timeline: skipped %d entries with unparsable timestamps\n", badTime)
+	}
+
+	f := os.Stdout
+	if *out != "-" {
+		f, err = os.Create(*out)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "timeline: %v\n", err)
+			os.Exit(1)
+		}
+		defer f.Close()
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"window_start", "elapsed_sec", "success", "conflict", "error"})
+	if len(buckets) > 0 {
+		// 빈 구간도 0 으로 채워 그래프가 끊기지 않게 한다
+		for k := first; k <= last; k++ {
+			b := buckets[k]
+			if b == nil {
+				b = &bucket{}
+			}
+			start := time.Unix(0, k*int64(*window))
+			elapsed := time.Duration((k - first) * int64(*window)).Seconds()
+			w.Write([]string{
+				start.UTC().Format(time.RFC3339Nano),
+				strconv.FormatFloat(elapsed, 'f', -1, 64),
+				strconv.Itoa(b.success),
+				strconv.Itoa(b.conflict),
+				strconv.Itoa(b.errors),
+			})
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		fmt.Fprintf(os.Stderr, "timeline: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"cmp"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	"ticketing-analysis/logs"
+)
+
+// 사용자 한 명의 예매 시도 기록
+type userStats struct {
+	userID     int
+	attempts   int
+	conflicts  int
+	errors     int
+	first      time.Time // 첫 시도
+	firstWin   time.Time // 첫 성공 (없으면 zero)
+	successful bool
+	toWin      int // 첫 성공까지의 시도 횟수 (성공 포함)
+}
+
+// 첫 성공까지 기다린 시간
+func (u *userStats) wait() time.Duration {
+	return u.firstWin.Sub(u.first)
+}
+
+// 사용자별 대기 시간 분석 (공정성, 기아 상태 확인용)
+// 요청 ID 와 서버 처리 시간이 로그에 없으므로 로그 시각만으로
+// 첫 시도부터 첫 성공까지 걸린 시간과 그 사이 시도 횟수를 계산한다
+func runUsers(args []string) {
+	fs := flag.NewFlagSet("users", flag.ExitOnError)
+	layout := fs.String("layout", "", "Go time layout of the timestamp field (default: RFC3339 or unix milliseconds)")
+	out := fs.String("o", "", "write per-user CSV to this path (\"-\" for stdout)")
+	fs.Parse(args)
+	if fs.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: analysis users [-layout LAYOUT] [-o users.csv] <ticketing.log>")
+		os.Exit(2)
+	}
+
+	users := make(map[int]*userStats)
+	_, err := logs.ReadFile(fs.Arg(0), func(e logs.Entry) {
+		if e.Action != "reserve" || e.UserID == 0 {
+			return
+		}
+		o := classify(e)
+		if o == outcomeNone {
+			return
+		}
+		t, err := logs.ParseTime(e.Timestamp, *layout)
+		if err != nil {
+			return
+		}
+
+		u := users[e.UserID]
+		if u == nil {
+			u = &userStats{userID: e.UserID, first: t}
+			users[e.UserID] = u
+		}
+		if t.Before(u.first) {
+			u.first = t
+		}
+		u.attempts++
+		switch o {
+		case outcomeSuccess:
+			// 로그는 기록 순서대로 읽으므로 처음 만난 성공이 첫 성공
+			if !u.successful {
+				u.firstWin = t
+				u.toWin = u.attempts
+			}
+			u.successful = true
+		case outcomeConflict:
+			u.conflicts++
+		case outcomeError:
+			u.errors++
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "users: %v\n", err)
+		os.Exit(1)
+	}
+
+	list := make([]*userStats, 0, len(users))
+	for _, u := range users {
+		list = append(list, u)
+	}
+	slices.SortFunc(list, func(a, b *userStats) int { return cmp.Compare(a.userID, b.userID) })
+
+	if *out != "" {
+		if err := writeUsersCSV(*out, list); err != nil {
+			fmt.Fprintf(os.Stderr, "users: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *out != "-" {
+		printUserSummary(list)
+	}
+}
+
+func writeUsersCSV(path string, list []*userStats) error {
+	f := os.Stdout
+	if path != "-" {
+		var err error
+		f, err = os.Create(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+	}
+
+	w := csv.NewWriter(f)
+	w.Write([]string{"user_id", "attempts", "conflicts", "errors", "succeeded", "attempts_to_win", "wait_sec"})
+	for _, u := range list {
+		toWin, wait := "", ""
+		if u.successful {
+			toWin = strconv.Itoa(u.toWin)
+			wait = strconv.FormatFloat(u.wait().Seconds(), 'f', 3, 64)
+		}
+		w.Write([]string{
+			strconv.Itoa(u.userID),
+			strconv.Itoa(u.attempts),
+			strconv.Itoa(u.conflicts),
+			strconv.Itoa(u.errors),
+			strconv.FormatBool(u.successful),
+			toWin,
+			wait,
+		})
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// 성공한 사용자의 대기 시간 분포와 끝내 실패한 사용자 수
+func printUserSummary(list []*userStats) {
+	var waits []time.Duration
+	var attempts []int
+	starved := 0
+	for _, u := range list {
+		if u.successful {
+			waits = append(waits, u.wait())
+			attempts = append(attempts, u.toWin)
+		} else {
+			starved++
+		}
+	}
+
+	fmt.Printf("Users: %d (succeeded %d, never succeeded %d)\n", len(list), len(waits), starved)
+	if len(waits) == 0 {
+		return
+	}
+	slices.Sort(waits)
+	slices.Sort(attempts)
+	fmt.Println("Wait until first success:")
+	for _, p := range []float64{50, 90, 99, 100} {
+		fmt.Printf("  p%-4v %v\n", p, waits[percentileIndex(len(waits), p)])
+	}
+	fmt.Println("Attempts until first success:")
+	for _, p := range []float64{50, 90, 99, 100} {
+		fmt.Printf("  p%-4v %d\n", p, attempts[percentileIndex(len(attempts), p)])
+	}
+}
+
+// 정렬된 n 개 값에서 p 백분위 위치 (nearest-rank, ceil(n*p/100) 번째)
+func percentileIndex(n int, p float64) int {
+	i := int(math.Ceil(float64(n)*p/100)) - 1
+	return min(max(i, 0), n-1)
+}
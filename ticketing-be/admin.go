@@ -0,0 +1,277 @@
+package main
+
+import (
+	"crypto/subtle"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// 예매 내역 (관리자 조회용)
+// reservation_id 는 예매마다 reservation_sequence 에서 발급한 번호 (reservation_seq 와 같은 값)
+// 순번을 발급하지 않는 autocommit 전략으로 예매한 좌석은 null
+type Reservation struct {
+	SeatID        int       `json:"seat_id"`
+	UserID        int       `json:"user_id"`
+	ReservationID *int64    `json:"reservation_id"`
+	ReservedAt    time.Time `json:"reserved_at"`
+	Seq           int64     `json:"reservation_seq,omitempty"`
+	OperatorID    int       `json:"operator_id,omitempty"` // 직원 대리 예매일 때만
+}
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 1000
+)
+
+// 관리자 토큰 검사 미들웨어
+// ADMIN_TOKEN 이 비어 있으면 관리자 API 자체를 막는다
+func requireAdmin(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := cfg.AdminToken
+		given := r.Header.Get("X-Admin-Token")
+		if token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(given)) != 1 {
+			http.Error(w, "Forbidden", http.StatusForbidden)
+			logJSON("WARN", "admin_auth", 0, 0, "forbidden", nil)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// limit, offset 쿼리 파라미터 파싱
+func parsePage(r *http.Request) (limit, offset int, ok bool) {
+	limit, offset = defaultPageLimit, 0
+	q := r.URL.Query()
+	if v := q.Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			return 0, 0, false
+		}
+		limit = min(n, maxPageLimit)
+	}
+	if v := q.Get("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return 0, 0, false
+		}
+		offset = n
+	}
+	return limit, offset, true
+}
+
+// 예매된 좌석 목록 반환 (페이지네이션)
+func adminReservationsHandler(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := parsePage(r)
+	if !ok {
+		http.Error(w, "Invalid limit or offset", http.StatusBadRequest)
+		logJSON("WARN", "admin_reservations", 0, 0, "bad_page_param", nil)
+		return
+	}
+
+	reservations, err := store.Reservations(limit, offset)
+	if err != nil {
+		logJSON("ERROR", "admin_reservations", 0, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	for i := range reservations {
+		if id := reservations[i].Seq; id != 0 {
+			reservations[i].ReservationID = &id
+		}
+	}
+
+	logJSON("INFO", "admin_reservations", 0, 0, fmt.Sprintf("count=%d", len(reservations)), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "admin_reservations", 0, 0, reservations)
+}
+
+// 예매된 좌석을 seat_id 순으로 limit 개 (offset 부터)
+func queryReservations(limit, offset int) ([]Reservation, error) {
+	rows, err := readDB.Query(`SELECT seat_id, user_id, reserved_at, COALESCE(reservation_seq, 0), COALESCE(operator_id, 0) FROM seats WHERE status = ? ORDER BY seat_id LIMIT ? OFFSET ?`, SeatReserved, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	reservations := make([]Reservation, 0)
+	for rows.Next() {
+		var res Reservation
+		if err := rows.Scan(&res.SeatID, &res.UserID, &res.ReservedAt, &res.Seq, &res.OperatorID); err == nil {
+			reservations = append(reservations, res)
+		}
+	}
+	return reservations, nil
+}
+
+// 사용자별 예매 좌석 수 (리더보드)
+type UserSeatCount struct {
+	UserID int `json:"user_id"`
+	Seats  int `json:"seats"`
+}
+
+const (
+	defaultLeaderboardTop = 10
+	maxLeaderboardTop     = 1000
+)
+
+// 예매 좌석이 가장 많은 사용자 목록 (?top=N, 좌석 편중 분석용)
+func adminLeaderboardHandler(w http.ResponseWriter, r *http.Request) {
+	top := defaultLeaderboardTop
+	if v := r.URL.Query().Get("top"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid top", http.StatusBadRequest)
+			logJSON("WARN", "admin_leaderboard", 0, 0, "bad_top", nil)
+			return
+		}
+		top = min(n, maxLeaderboardTop)
+	}
+
+	rows, err := readDB.Query(`SELECT user_id, COUNT(*) AS n FROM seats WHERE status = ? GROUP BY user_id ORDER BY n DESC, user_id LIMIT ?`, SeatReserved, top)
+	if err != nil {
+		logJSON("ERROR", "admin_leaderboard", 0, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	leaders := make([]UserSeatCount, 0, top)
+	for rows.Next() {
+		var c UserSeatCount
+		if err := rows.Scan(&c.UserID, &c.Seats); err == nil {
+			leaders = append(leaders, c)
+		}
+	}
+
+	logJSON("INFO", "admin_leaderboard", 0, 0, fmt.Sprintf("count=%d", len(leaders)), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "admin_leaderboard", 0, 0, leaders)
+}
+
+// 좌석 한 개의 현재 상태 (관리자 조회용)
+type SeatState struct {
+	SeatID int        `json:"seat_id"`
+	Status SeatStatus `json:"status"`
+	UserID int        `json:"user_id,omitempty"`
+}
+
+// 좌석 한 개의 상태와 예매자 조회 (?seat_id=N)
+// 응답을 받지 못한 예매가 실제로 반영되었는지 클라이언트가 확인할 때 쓴다
+func adminSeatHandler(w http.ResponseWriter, r *http.Request) {
+	seatID, err := strconv.Atoi(r.URL.Query().Get("seat_id"))
+	if err != nil || seatID <= 0 {
+		http.Error(w, "Invalid seat_id", http.StatusBadRequest)
+		logJSON("WARN", "admin_seat", 0, 0, "bad_seat_id", nil)
+		return
+	}
+
+	st := SeatState{SeatID: seatID}
+	var owner sql.NullInt64
+	err = db.QueryRow(`SELECT status, user_id FROM seats WHERE seat_id = ?`, seatID).Scan(&st.Status, &owner)
+	if err == sql.ErrNoRows {
+		http.Error(w, "Seat not found", http.StatusNotFound)
+		logJSON("WARN", "admin_seat", 0, seatID, "seat_not_found", nil)
+		return
+	} else if err != nil {
+		logJSON("ERROR", "admin_seat", 0, seatID, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	st.UserID = int(owner.Int64)
+
+	logJSON("INFO", "admin_seat", st.UserID, seatID, string(st.Status), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "admin_seat", st.UserID, seatID, st)
+}
+
+type SeatIDsRequest struct {
+	SeatIDs []int `json:"seat_ids"`
+}
+
+// 좌석 판매 중지/재개 (관리자)
+// from 상태인 좌석만 to 상태로 바꾸고, 실제로 바뀐 좌석 ID 를 돌려준다
+func adminSetSeatsHandler(from, to SeatStatus) http.HandlerFunc {
+	action := "admin_seats_" + string(to)
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+			http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+			logJSON("WARN", action, 0, 0, "bad_content_type", nil)
+			return
+		}
+
+		var req SeatIDsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.SeatIDs) == 0 {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			logJSON("ERROR", action, 0, 0, "invalid_json", err)
+			return
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			logJSON("ERROR", action, 0, 0, "tx_begin_fail", err)
+			return
+		}
+		defer tx.Rollback()
+
+		changed := make([]int, 0, len(req.SeatIDs))
+		for _, id := range req.SeatIDs {
+			res, err := tx.Exec(`UPDATE seats SET status = ? WHERE seat_id = ? AND status = ?`, to, id, from)
+			if err != nil {
+				http.Error(w, "internal server error", http.StatusInternalServerError)
+				logJSON("ERROR", action, 0, id, "update_fail", err)
+				return
+			}
+			if n, _ := res.RowsAffected(); n > 0 {
+				changed = append(changed, id)
+			}
+		}
+
+		if err := tx.Commit(); err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			logJSON("ERROR", action, 0, 0, "commit_fail", err)
+			return
+		}
+
+		logJSON("INFO", action, 0, 0, fmt.Sprintf("changed=%d", len(changed)), nil)
+		seatCounts.move(from, to, len(changed))
+		w.Header().Set("Content-Type", "application/json")
+		invalidateSeatCache()
+		encodeJSON(w, action, 0, 0, map[string]any{"seat_ids": changed})
+	}
+}
+
+// 모든 예매를 취소해 좌석을 빈 좌석으로 되돌림 (부하 테스트 반복용, 판매 중지 좌석은 유지)
+func adminResetHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		logJSON("WARN", "admin_reset", 0, 0, "bad_method", nil)
+		return
+	}
+
+	res, err := db.Exec(`UPDATE seats SET status = ?, user_id = NULL, reserved_at = NULL, nonce = NULL, reservation_seq = NULL, operator_id = NULL, confirmation_code = NULL WHERE status = ?`, SeatAvailable, SeatReserved)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "admin_reset", 0, 0, "update_fail", err)
+		return
+	}
+	n, _ := res.RowsAffected()
+
+	logJSON("INFO", "admin_reset", 0, 0, fmt.Sprintf("freed=%d", n), nil)
+	seatCounts.move(SeatReserved, SeatAvailable, int(n))
+	w.Header().Set("Content-Type", "application/json")
+	invalidateSeatCache()
+	encodeJSON(w, "admin_reset", 0, 0, map[string]any{"freed": n})
+}
+
+// 적용 중인 서버 설정 반환 (비밀 값은 Config 의 json 태그로 제외됨)
+func adminConfigHandler(w http.ResponseWriter, r *http.Request) {
+	logJSON("INFO", "admin_config", 0, 0, "ok", nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "admin_config", 0, 0, cfg)
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type AnyRequest struct {
+	UserID int `json:"user_id"`
+	Count  int `json:"count"` // 생략하면 1
+}
+
+const maxAnyCount = 20
+
+// 좌석을 지정하지 않고 빈 좌석 최대 count 개 예매
+// SKIP LOCKED 로 다른 트랜잭션이 잡고 있는 행은 기다리지 않고 건너뛰므로
+// 재고가 부족하면 요청보다 적게 배정될 수 있다
+func reserveAnyHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_any", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req AnyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_any", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "reserve_any", req.UserID, 0) {
+		return
+	}
+	if req.Count == 0 {
+		req.Count = 1
+	}
+	if req.Count < 0 || req.Count > maxAnyCount {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxAnyCount), http.StatusBadRequest)
+		logJSON("WARN", "reserve_any", req.UserID, 0, "bad_count", nil)
+		return
+	}
+
+	tx, err := beginReserveTx()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_any", req.UserID, 0, "tx_begin_fail", err)
+		return
+	}
+	defer tx.Rollback()
+
+	// SKIP LOCKED 라 좌석은 기다리지 않지만 상한 행 잠금은 기다린다
+	if err := setLockWaitTimeout(tx); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_any", req.UserID, 0, "lock_timeout_set_fail", err)
+		return
+	}
+
+	rows, err := tx.Query(`SELECT seat_id FROM seats WHERE status = ? ORDER BY seat_id LIMIT ? FOR UPDATE SKIP LOCKED`, SeatAvailable, req.Count)
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_any", req.UserID, 0, "select_fail", err)
+		return
+	}
+	seatIDs := make([]int, 0, req.Count)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err == nil {
+			seatIDs = append(seatIDs, id)
+		}
+	}
+	rows.Close()
+
+	if len(seatIDs) == 0 {
+		http.Error(w, localize(r, "No seats available"), http.StatusConflict)
+		logJSON("INFO", "reserve_any", req.UserID, 0, "sold_out", nil)
+		return
+	}
+
+	// 좌석을 잠근 뒤 상한을 확인하고 남은 수만큼만 배정
+	left, ok := checkReservationCap(w, tx, "reserve_any", req.UserID, 0, 1)
+	if !ok {
+		return
+	}
+	if left >= 0 && len(seatIDs) > left {
+		seatIDs = seatIDs[:left]
+	}
+
+	reserved, err := reserveSeatsTx(tx, req.UserID, seatIDs)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_any", req.UserID, 0, stage, cause)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_any", req.UserID, 0, "commit_fail", err)
+		return
+	}
+
+	logJSON("INFO", "reserve_any", req.UserID, 0, fmt.Sprintf("success=%d requested=%d", len(seatIDs), req.Count), nil)
+	afterReserve(req.UserID, seatIDs, reserved[0].ReservedAt)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "reserve_any", req.UserID, 0, map[string]any{
+		"message":            localize(r, "Reservation successful"),
+		"seat_ids":           seatIDs,
+		"confirmation_codes": confirmationCodes(reserved),
+		"reserved_at":        reserved[0].ReservedAt,
+	})
+}
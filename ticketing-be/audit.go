@@ -0,0 +1,49 @@
+package main
+
+// DB 트리거 기반 좌석 상태 변경 감사 (AUDIT_TRIGGER)
+// 애플리케이션 로그와 달리 서버를 거치지 않은 SQL 로 바뀐 것도 남는다
+
+const auditTableDDL = `
+	CREATE TABLE IF NOT EXISTS seat_audit (
+		id BIGINT AUTO_INCREMENT PRIMARY KEY,
+		seat_id INT NOT NULL,
+		old_status VARCHAR(20),
+		new_status VARCHAR(20),
+		old_user_id INT,
+		new_user_id INT,
+		changed_at DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6)
+	)`
+
+// 상태나 예매자가 실제로 바뀐 경우만 기록 (<=> 는 NULL 안전 비교)
+const auditTriggerDDL = `
+	CREATE TRIGGER seats_audit AFTER UPDATE ON seats
+	FOR EACH ROW
+	BEGIN
+		IF NOT (OLD.status <=> NEW.status) OR NOT (OLD.user_id <=> NEW.user_id) THEN
+			INSERT INTO seat_audit (seat_id, old_status, new_status, old_user_id, new_user_id)
+			VALUES (NEW.seat_id, OLD.status, NEW.status, OLD.user_id, NEW.user_id);
+		END IF;
+	END`
+
+// 감사 트리거 생성 또는 제거
+// 꺼져 있으면 이전 실행에서 만든 트리거를 지워 측정에 영향이 없게 한다 (감사 테이블은 남김)
+func setupAuditTrigger(enabled bool) error {
+	if _, err := db.Exec(`DROP TRIGGER IF EXISTS seats_audit`); err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "drop_audit_trigger_fail", err)
+		return err
+	}
+	if !enabled {
+		return nil
+	}
+
+	if _, err := db.Exec(auditTableDDL); err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "create_audit_table_fail", err)
+		return err
+	}
+	if _, err := db.Exec(auditTriggerDDL); err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "create_audit_trigger_fail", err)
+		return err
+	}
+	logJSON("INFO", "init_seats", 0, 0, "audit_trigger_enabled", nil)
+	return nil
+}
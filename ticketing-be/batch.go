@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+type BatchRequest struct {
+	UserID  int   `json:"user_id"`
+	SeatIDs []int `json:"seat_ids"`
+}
+
+// 예매하지 못한 좌석과 사유
+type SeatFailure struct {
+	SeatID int    `json:"seat_id"`
+	Reason string `json:"reason"`
+}
+
+type BatchResponse struct {
+	Message    string         `json:"message"`
+	Succeeded  []int          `json:"succeeded"`
+	Failed     []SeatFailure  `json:"failed"`
+	Codes      map[int]string `json:"confirmation_codes,omitempty"` // seat_id -> 확인 코드
+	ReservedAt time.Time      `json:"reserved_at,omitzero"`         // 성공한 좌석들의 예매 시각
+}
+
+const maxBatchCount = 20
+
+// 여러 좌석 일괄 예매
+// 기본은 전부 성공하거나 전부 실패, ?partial=true 이면 가능한 좌석만 예매하고 207 로 결과를 나눠 돌려준다
+func reserveBatchHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_batch", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req BatchRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_batch", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "reserve_batch", req.UserID, 0) {
+		return
+	}
+	slices.Sort(req.SeatIDs) // 잠금 순서를 고정해 교착 상태 방지
+	req.SeatIDs = slices.Compact(req.SeatIDs)
+	if len(req.SeatIDs) == 0 || len(req.SeatIDs) > maxBatchCount {
+		http.Error(w, fmt.Sprintf("seat_ids must contain between 1 and %d seats", maxBatchCount), http.StatusBadRequest)
+		logJSON("WARN", "reserve_batch", req.UserID, 0, "bad_count", nil)
+		return
+	}
+	partial := r.URL.Query().Get("partial") == "true"
+
+	tx, err := beginReserveTx()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_batch", req.UserID, 0, "tx_begin_fail", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_batch", req.UserID, 0, "lock_timeout_set_fail", err)
+		return
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(req.SeatIDs)), ",")
+	args := make([]any, len(req.SeatIDs))
+	for i, id := range req.SeatIDs {
+		args[i] = id
+	}
+
+	rows, err := tx.Query(`SELECT seat_id, status FROM seats WHERE seat_id IN (`+placeholders+`) ORDER BY seat_id FOR UPDATE`, args...)
+	if isLockWaitTimeout(err) {
+		http.Error(w, localize(r, "Seats are locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "reserve_batch", req.UserID, 0, "lock_wait_timeout", err)
+		return
+	} else if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_batch", req.UserID, 0, "select_fail", err)
+		return
+	}
+	statuses := make(map[int]SeatStatus)
+	for rows.Next() {
+		var id int
+		var status SeatStatus
+		if err := rows.Scan(&id, &status); err == nil {
+			statuses[id] = status
+		}
+	}
+	rows.Close()
+
+	// 좌석 행을 잠근 뒤에 상한 확인 (잠금 순서: 좌석 → 상한)
+	need := len(req.SeatIDs)
+	if partial {
+		need = 1
+	}
+	left, ok := checkReservationCap(w, tx, "reserve_batch", req.UserID, 0, need)
+	if !ok {
+		return
+	}
+
+	resp := BatchResponse{Succeeded: []int{}, Failed: []SeatFailure{}}
+	for _, id := range req.SeatIDs {
+		switch status, ok := statuses[id]; {
+		case !ok:
+			resp.Failed = append(resp.Failed, SeatFailure{SeatID: id, Reason: "seat_not_found"})
+		case status == SeatDisabled:
+			resp.Failed = append(resp.Failed, SeatFailure{SeatID: id, Reason: "seat_disabled"})
+		case status != SeatAvailable:
+			resp.Failed = append(resp.Failed, SeatFailure{SeatID: id, Reason: "seat_conflict"})
+		default:
+			resp.Succeeded = append(resp.Succeeded, id)
+		}
+	}
+
+	// 부분 예매는 상한까지만 배정하고 나머지는 실패로 돌린다
+	if left >= 0 && len(resp.Succeeded) > left {
+		for _, id := range resp.Succeeded[left:] {
+			resp.Failed = append(resp.Failed, SeatFailure{SeatID: id, Reason: "sold_out"})
+		}
+		resp.Succeeded = resp.Succeeded[:left]
+	}
+
+	if len(resp.Succeeded) == 0 || (!partial && len(resp.Failed) > 0) {
+		resp.Message = localize(r, "Reservation failed")
+		resp.Succeeded = []int{}
+		logJSON("INFO", "reserve_batch", req.UserID, 0, "seat_conflict", nil)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		encodeJSON(w, "reserve_batch", req.UserID, 0, resp)
+		return
+	}
+
+	reserved, err := reserveSeatsTx(tx, req.UserID, resp.Succeeded)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_batch", req.UserID, 0, stage, cause)
+		return
+	}
+	resp.Codes = confirmationCodes(reserved)
+	resp.ReservedAt = reserved[0].ReservedAt
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_batch", req.UserID, 0, "commit_fail", err)
+		return
+	}
+
+	code := http.StatusOK
+	resp.Message = localize(r, "Reservation successful")
+	if len(resp.Failed) > 0 {
+		code = http.StatusMultiStatus
+		resp.Message = localize(r, "Reservation partially successful")
+	}
+	logJSON("INFO", "reserve_batch", req.UserID, 0, fmt.Sprintf("success=%d failed=%d", len(resp.Succeeded), len(resp.Failed)), nil)
+	afterReserve(req.UserID, resp.Succeeded, resp.ReservedAt)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encodeJSON(w, "reserve_batch", req.UserID, 0, resp)
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// 빈 좌석 비트맵 (/seats/available?format=bitmap)
+//
+// 좌석 하나당 1 비트, 1 이면 빈 좌석. 비트 순서:
+//   - k 번째 비트는 seat_id = base + k (base 는 X-Seat-Base 헤더, 범위를 안 주면 1, 주면 from)
+//   - k 번째 비트는 k/8 번째 바이트의 (k%8) 번 비트 (최하위 비트부터, LSB-first)
+//
+// 길이는 X-Seat-Count 헤더의 좌석 수를 8 로 나눠 올림한 바이트 수
+// 예: 좌석 1, 3, 10 이 비었고 base=1, 10 석이면 0b00000101, 0b00000010 두 바이트
+func seatBitmap(seats []int, base, count int) []byte {
+	bitmap := make([]byte, (count+7)/8)
+	for _, id := range seats {
+		if k := id - base; k >= 0 && k < count {
+			bitmap[k/8] |= 1 << (k % 8)
+		}
+	}
+	return bitmap
+}
+
+// 비트맵 응답 작성
+// 범위를 주면 from ~ min(to, SEAT_COUNT), 아니면 1 ~ SEAT_COUNT 를 담는다
+func writeSeatBitmap(w http.ResponseWriter, seats []int, from, to int, ranged bool) {
+	base, last := 1, cfg.SeatCount
+	if ranged {
+		base, last = from, min(to, cfg.SeatCount)
+	}
+	count := max(last-base+1, 0)
+
+	w.Header().Set("Content-Type", "application/octet-stream")
+	w.Header().Set("X-Seat-Base", strconv.Itoa(base))
+	w.Header().Set("X-Seat-Count", strconv.Itoa(count))
+	if _, err := w.Write(seatBitmap(seats, base, count)); err != nil {
+		logJSON("ERROR", "available_seats", 0, 0, "write_fail", err)
+	}
+}
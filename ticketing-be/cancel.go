@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type CancelAllRequest struct {
+	UserID int `json:"user_id"`
+}
+
+// 좌석 예매 취소 처리 (본인이 예매한 좌석만)
+func cancelHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "cancel", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req TicketRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "cancel", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "cancel", req.UserID, req.SeatID) {
+		return
+	}
+
+	outcome, err := store.Cancel(req.UserID, req.SeatID)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "cancel", req.UserID, req.SeatID, stage, cause)
+		return
+	}
+
+	switch outcome {
+	case reserveNotFound:
+		http.Error(w, localize(r, "Seat not found"), http.StatusNotFound)
+		logJSON("WARN", "cancel", req.UserID, req.SeatID, "seat_not_found", nil)
+		return
+	case reserveLockTimeout:
+		http.Error(w, localize(r, "Seat is locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "cancel", req.UserID, req.SeatID, "lock_wait_timeout", nil)
+		return
+	case reserveNotOwned:
+		http.Error(w, localize(r, "Seat is not reserved by this user"), http.StatusConflict)
+		logJSON("INFO", "cancel", req.UserID, req.SeatID, "seat_not_owned", nil)
+		return
+	}
+
+	logJSON("INFO", "cancel", req.UserID, req.SeatID, "success", nil)
+	seatCounts.move(SeatReserved, SeatAvailable, 1)
+	w.Header().Set("Content-Type", "application/json")
+	invalidateSeatCache()
+	encodeJSON(w, "cancel", req.UserID, req.SeatID, map[string]string{
+		"message": localize(r, "Cancellation successful"),
+	})
+}
+
+// 사용자의 모든 예매 일괄 취소
+func cancelAllHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "cancel_all", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req CancelAllRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "cancel_all", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "cancel_all", req.UserID, 0) {
+		return
+	}
+
+	freed, outcome, err := store.CancelAll(req.UserID)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "cancel_all", req.UserID, 0, stage, cause)
+		return
+	}
+	if outcome == reserveLockTimeout {
+		http.Error(w, localize(r, "Seats are locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "cancel_all", req.UserID, 0, "lock_wait_timeout", nil)
+		return
+	}
+
+	logJSON("INFO", "cancel_all", req.UserID, 0, fmt.Sprintf("freed=%d", len(freed)), nil)
+	seatCounts.move(SeatReserved, SeatAvailable, len(freed))
+	w.Header().Set("Content-Type", "application/json")
+	if len(freed) > 0 {
+		invalidateSeatCache()
+	}
+	encodeJSON(w, "cancel_all", req.UserID, 0, map[string]any{
+		"message":  localize(r, "Cancellation successful"),
+		"seat_ids": freed,
+	})
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"database/sql"
+	"net/http"
+)
+
+// 전체 예매 수 상한 (MAX_TOTAL_RESERVATIONS)
+// 좌석이 남아 있어도 상한에 닿으면 410 sold_out 으로 더 받지 않는다
+
+// 상한 확인을 직렬화하는 한 행짜리 잠금 테이블
+// 예매 수를 세기 전에 이 행을 잠가 동시 요청이 같은 개수를 보고 함께 넘어가는 것을 막는다
+const reservationCapDDL = `
+	CREATE TABLE IF NOT EXISTS reservation_cap (
+		id TINYINT PRIMARY KEY
+	)`
+
+func setupReservationCap() error {
+	if _, err := db.Exec(reservationCapDDL); err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "create_cap_table_fail", err)
+		return err
+	}
+	if _, err := db.Exec(`INSERT IGNORE INTO reservation_cap (id) VALUES (1)`); err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "insert_cap_row_fail", err)
+		return err
+	}
+	return nil
+}
+
+// 상한까지 더 예매할 수 있는 좌석 수 (상한이 없으면 -1)
+// 모든 예매 경로는 좌석 행을 먼저 잠그고 UPDATE 직전에 이 행을 잠근다 (좌석 → 상한 순서)
+// 결제처럼 좌석을 오래 잡는 경로가 있어도 상한 행은 짧게만 잡혀 다른 예매가 그 뒤에 줄 서지 않는다
+// 상한이 켜져 있으면 예매 트랜잭션이 이 행에서 줄을 서므로 처리량이 떨어진다
+// REPEATABLE READ 에서는 첫 일반 (잠금 없는) 읽기 시점의 스냅샷으로 세므로, 트랜잭션 안에서 이보다 먼저 일반 읽기를 하면 안 된다
+// (FOR UPDATE 같은 잠금 읽기는 스냅샷을 만들지 않는다)
+func reservationsLeft(tx *sql.Tx) (int, error) {
+	if cfg.MaxReservations <= 0 {
+		return -1, nil
+	}
+	if err := lockReservationCap(tx); err != nil {
+		return 0, err
+	}
+	return countReservationsLeft(tx)
+}
+
+func lockReservationCap(tx *sql.Tx) error {
+	var id int
+	return tx.QueryRow(`SELECT id FROM reservation_cap WHERE id = 1 FOR UPDATE`).Scan(&id)
+}
+
+// 이 트랜잭션이 방금 UPDATE 한 좌석까지 세어 상한을 넘었는지 (조건부 UPDATE 로 좌석을 잠그는 optimistic 전략용)
+func reservationCapExceeded(tx *sql.Tx) (bool, error) {
+	if cfg.MaxReservations <= 0 {
+		return false, nil
+	}
+	if err := lockReservationCap(tx); err != nil {
+		return false, err
+	}
+	var n int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM seats WHERE status = ?`, SeatReserved).Scan(&n); err != nil {
+		return false, err
+	}
+	return n > cfg.MaxReservations, nil
+}
+
+// 잠금 없이 남은 수만 계산 (트랜잭션이 없는 autocommit 전략용, 동시 요청에서는 상한을 조금 넘을 수 있다)
+func countReservationsLeft(q interface {
+	QueryRow(query string, args ...any) *sql.Row
+}) (int, error) {
+	if cfg.MaxReservations <= 0 {
+		return -1, nil
+	}
+	var n int
+	if err := q.QueryRow(`SELECT COUNT(*) FROM seats WHERE status = ?`, SeatReserved).Scan(&n); err != nil {
+		return 0, err
+	}
+	return max(cfg.MaxReservations-n, 0), nil
+}
+
+// 여러 좌석을 예매하는 핸들러용 상한 확인
+// need 개를 더 예매할 수 없으면 응답을 쓰고 ok=false, 아니면 남은 수 (상한이 없으면 -1)
+func checkReservationCap(w http.ResponseWriter, tx *sql.Tx, action string, userID, seatID, need int) (int, bool) {
+	left, err := reservationsLeft(tx)
+	if isLockWaitTimeout(err) {
+		setRetryAfter(w)
+		http.Error(w, "Reservations are locked by another transaction", http.StatusConflict)
+		logJSON("INFO", action, userID, seatID, "lock_wait_timeout", err)
+		return 0, false
+	} else if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", action, userID, seatID, "cap_check_fail", err)
+		return 0, false
+	}
+	if left >= 0 && left < need {
+		http.Error(w, "sold_out", http.StatusGone)
+		logJSON("INFO", action, userID, seatID, "sold_out", nil)
+		return 0, false
+	}
+	return left, true
+}
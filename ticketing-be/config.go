@@ -0,0 +1,347 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// 서버 설정 (환경 변수에서 로드)
+type Config struct {
+	DBHost              string        `json:"db_host"`
+	DBReadHost          string        `json:"db_read_host,omitempty"`
+	DBPort              int           `json:"db_port"`
+	DBUser              string        `json:"db_user"`
+	DBPassword          string        `json:"-"`
+	DBName              string        `json:"db_name"`
+	MaxOpenConns        int           `json:"max_open_conns"`
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	ConnMaxLifetime     time.Duration `json:"conn_max_lifetime"`
+	SeatCount           int           `json:"seat_count"`
+	SeatInitBatch       int           `json:"seat_init_batch"`
+	ListenAddr          string        `json:"listen_addr"`
+	LogDir              string        `json:"log_dir"`
+	AdminToken          string        `json:"-"`
+	FaultInjectRate     float64       `json:"fault_inject_rate"`
+	BreakerThreshold    int           `json:"breaker_threshold"`
+	BreakerCooldown     time.Duration `json:"breaker_cooldown"`
+	WebhookURL          string        `json:"webhook_url,omitempty"`
+	WebhookQueueSize    int           `json:"webhook_queue_size"`
+	WebhookWorkers      int           `json:"webhook_workers"`
+	WebhookTimeout      time.Duration `json:"webhook_timeout"`
+	LockWaitTimeout     int           `json:"lock_wait_timeout_sec"`
+	ReserveStrategy     string        `json:"reserve_strategy"`
+	Notifier            string        `json:"notifier"`
+	SeatRows            int           `json:"seat_rows"`
+	SeatColumns         int           `json:"seat_columns"`
+	Sections            []SeatSection `json:"sections"`
+	DBStatsInterval     time.Duration `json:"db_stats_interval"`
+	LogTimeFormat       string        `json:"log_time_format"`
+	ArtificialDelayMS   int           `json:"artificial_delay_ms"`
+	TxIsolation         string        `json:"tx_isolation"`
+	JWTSecret           string        `json:"-"`
+	DBPrewarm           int           `json:"db_prewarm"`
+	ConflictRetryAfter  int           `json:"conflict_retry_after_sec"`
+	AuditTrigger        bool          `json:"audit_trigger"`
+	SelfCheckStrict     bool          `json:"self_check_strict"`
+	ConflictShowOwner   bool          `json:"conflict_show_owner"`
+	DBTimeout           time.Duration `json:"db_timeout"`
+	DBReadTimeout       time.Duration `json:"db_read_timeout"`
+	DBWriteTimeout      time.Duration `json:"db_write_timeout"`
+	DBInterpolateParams bool          `json:"db_interpolate_params"`
+	MaxReservations     int           `json:"max_total_reservations"`
+	MessagesFile        string        `json:"messages_file"`
+	PerIPInflight       int           `json:"per_ip_max_inflight"`
+	SeatCountReconcile  time.Duration `json:"seat_count_reconcile"`
+	PaymentLatency      time.Duration `json:"payment_latency"`
+	PaymentFailRate     float64       `json:"payment_fail_rate"`
+	SeatFIFO            bool          `json:"seat_fifo"`
+	LogFieldNames       string        `json:"log_field_names"`
+	GRPCAddr            string        `json:"grpc_addr"`
+}
+
+var cfg Config
+
+// 환경 변수 읽기 도우미
+// 잘못된 값은 errs 에 필드 이름과 함께 쌓는다
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) String(key, def string) string {
+	if v, ok := os.LookupEnv(key); ok {
+		return v
+	}
+	return def
+}
+
+func (e *envReader) Int(key string, def int) int {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not an integer", key, v))
+		return def
+	}
+	return n
+}
+
+func (e *envReader) Bool(key string, def bool) bool {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a boolean", key, v))
+		return def
+	}
+	return b
+}
+
+func (e *envReader) Float(key string, def float64) float64 {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a number", key, v))
+		return def
+	}
+	return f
+}
+
+func (e *envReader) Sections(key string) []SeatSection {
+	sections, err := parseSections(os.Getenv(key))
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %w", key, err))
+	}
+	return sections
+}
+
+func (e *envReader) Duration(key string, def time.Duration) time.Duration {
+	v, ok := os.LookupEnv(key)
+	if !ok || v == "" {
+		return def
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("%s: %q is not a duration", key, v))
+		return def
+	}
+	return d
+}
+
+// 설정 로드 및 검증
+func loadConfig() (Config, error) {
+	var env envReader
+	c := Config{
+		DBHost:              env.String("DB_HOST", "db"),
+		DBReadHost:          env.String("DB_READ_HOST", ""),
+		DBPort:              env.Int("DB_PORT", 3306),
+		DBUser:              env.String("DB_USER", "root"),
+		DBPassword:          env.String("DB_PASSWORD", "password"),
+		DBName:              env.String("DB_NAME", "ticketing"),
+		MaxOpenConns:        env.Int("DB_MAX_OPEN_CONNS", 5000),
+		MaxIdleConns:        env.Int("DB_MAX_IDLE_CONNS", 100),
+		ConnMaxLifetime:     env.Duration("DB_CONN_MAX_LIFETIME", 30*time.Second),
+		SeatCount:           env.Int("SEAT_COUNT", 10000),
+		SeatInitBatch:       env.Int("SEAT_INIT_BATCH", 1000),
+		ListenAddr:          env.String("LISTEN_ADDR", ":8080"),
+		LogDir:              env.String("LOG_DIR", "/results"),
+		AdminToken:          env.String("ADMIN_TOKEN", ""),
+		FaultInjectRate:     env.Float("FAULT_INJECT_RATE", 0),
+		BreakerThreshold:    env.Int("CIRCUIT_BREAKER_THRESHOLD", 0),
+		BreakerCooldown:     env.Duration("CIRCUIT_BREAKER_COOLDOWN", 10*time.Second),
+		WebhookURL:          env.String("WEBHOOK_URL", ""),
+		WebhookQueueSize:    env.Int("WEBHOOK_QUEUE_SIZE", 1000),
+		WebhookWorkers:      env.Int("WEBHOOK_WORKERS", 4),
+		WebhookTimeout:      env.Duration("WEBHOOK_TIMEOUT", 5*time.Second),
+		LockWaitTimeout:     env.Int("LOCK_WAIT_TIMEOUT_SEC", 0),
+		ReserveStrategy:     env.String("RESERVE_STRATEGY", strategyPessimistic),
+		Notifier:            env.String("NOTIFIER", "none"),
+		SeatRows:            env.Int("SEAT_ROWS", 0),
+		SeatColumns:         env.Int("SEAT_COLUMNS", 0),
+		Sections:            env.Sections("SEAT_SECTIONS"),
+		DBStatsInterval:     env.Duration("DB_STATS_INTERVAL", 10*time.Second),
+		LogTimeFormat:       env.String("LOG_TIME_FORMAT", "rfc3339"),
+		ArtificialDelayMS:   env.Int("ARTIFICIAL_DELAY_MS", 0),
+		TxIsolation:         env.String("TX_ISOLATION", ""),
+		JWTSecret:           env.String("JWT_SECRET", ""),
+		DBPrewarm:           env.Int("DB_PREWARM", 0),
+		ConflictRetryAfter:  env.Int("CONFLICT_RETRY_AFTER_SEC", 0),
+		AuditTrigger:        env.Bool("AUDIT_TRIGGER", false),
+		SelfCheckStrict:     env.Bool("SELF_CHECK_STRICT", false),
+		ConflictShowOwner:   env.Bool("CONFLICT_SHOW_OWNER", false),
+		DBTimeout:           env.Duration("DB_TIMEOUT", 0),
+		DBReadTimeout:       env.Duration("DB_READ_TIMEOUT", 0),
+		DBWriteTimeout:      env.Duration("DB_WRITE_TIMEOUT", 0),
+		DBInterpolateParams: env.Bool("DB_INTERPOLATE_PARAMS", false),
+		MaxReservations:     env.Int("MAX_TOTAL_RESERVATIONS", 0),
+		MessagesFile:        env.String("MESSAGES_FILE", ""),
+		PerIPInflight:       env.Int("PER_IP_MAX_INFLIGHT", 0),
+		SeatCountReconcile:  env.Duration("SEAT_COUNT_RECONCILE", 30*time.Second),
+		PaymentLatency:      env.Duration("PAYMENT_LATENCY", 200*time.Millisecond),
+		PaymentFailRate:     env.Float("PAYMENT_FAIL_RATE", 0),
+		SeatFIFO:            env.Bool("SEAT_FIFO", false),
+		LogFieldNames:       env.String("LOG_FIELD_NAMES", ""),
+		GRPCAddr:            env.String("GRPC_ADDR", ":9090"),
+	}
+
+	errs := env.errs
+	if c.DBHost == "" {
+		errs = append(errs, errors.New("DB_HOST: must not be empty"))
+	}
+	if c.DBPort <= 0 || c.DBPort > 65535 {
+		errs = append(errs, fmt.Errorf("DB_PORT: %d is out of range", c.DBPort))
+	}
+	if c.DBName == "" {
+		errs = append(errs, errors.New("DB_NAME: must not be empty"))
+	}
+	if c.MaxOpenConns <= 0 {
+		errs = append(errs, fmt.Errorf("DB_MAX_OPEN_CONNS: must be positive, got %d", c.MaxOpenConns))
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConns > c.MaxOpenConns {
+		errs = append(errs, fmt.Errorf("DB_MAX_IDLE_CONNS: must be between 0 and DB_MAX_OPEN_CONNS (%d), got %d", c.MaxOpenConns, c.MaxIdleConns))
+	}
+	if c.ConnMaxLifetime < 0 {
+		errs = append(errs, fmt.Errorf("DB_CONN_MAX_LIFETIME: must not be negative, got %v", c.ConnMaxLifetime))
+	}
+	if c.SeatCount <= 0 {
+		errs = append(errs, fmt.Errorf("SEAT_COUNT: must be positive, got %d", c.SeatCount))
+	}
+	if c.SeatInitBatch <= 0 {
+		errs = append(errs, fmt.Errorf("SEAT_INIT_BATCH: must be positive, got %d", c.SeatInitBatch))
+	}
+	if c.FaultInjectRate < 0 || c.FaultInjectRate > 1 {
+		errs = append(errs, fmt.Errorf("FAULT_INJECT_RATE: must be between 0.0 and 1.0, got %v", c.FaultInjectRate))
+	}
+	if c.BreakerThreshold < 0 {
+		errs = append(errs, fmt.Errorf("CIRCUIT_BREAKER_THRESHOLD: must not be negative, got %d", c.BreakerThreshold))
+	}
+	if c.BreakerCooldown <= 0 {
+		errs = append(errs, fmt.Errorf("CIRCUIT_BREAKER_COOLDOWN: must be positive, got %v", c.BreakerCooldown))
+	}
+	if c.WebhookQueueSize <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_QUEUE_SIZE: must be positive, got %d", c.WebhookQueueSize))
+	}
+	if c.WebhookWorkers <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_WORKERS: must be positive, got %d", c.WebhookWorkers))
+	}
+	if c.WebhookTimeout <= 0 {
+		errs = append(errs, fmt.Errorf("WEBHOOK_TIMEOUT: must be positive, got %v", c.WebhookTimeout))
+	}
+	if c.LockWaitTimeout < 0 {
+		errs = append(errs, fmt.Errorf("LOCK_WAIT_TIMEOUT_SEC: must not be negative, got %d", c.LockWaitTimeout))
+	}
+	if c.ReserveStrategy != strategyPessimistic && c.ReserveStrategy != strategyOptimistic && c.ReserveStrategy != strategyAutocommit {
+		errs = append(errs, fmt.Errorf("RESERVE_STRATEGY: must be %q, %q or %q, got %q", strategyPessimistic, strategyOptimistic, strategyAutocommit, c.ReserveStrategy))
+	}
+	if _, err := newNotifier(c.Notifier); err != nil {
+		errs = append(errs, fmt.Errorf("NOTIFIER: %w", err))
+	}
+	if c.SeatRows != 0 || c.SeatColumns != 0 {
+		// 배치가 설정되면 좌석 수는 행 × 열로 정해진다
+		if c.SeatRows <= 0 || c.SeatColumns <= 0 {
+			errs = append(errs, fmt.Errorf("SEAT_ROWS, SEAT_COLUMNS: must both be positive, got %d x %d", c.SeatRows, c.SeatColumns))
+		} else if _, set := os.LookupEnv("SEAT_COUNT"); set && c.SeatCount != c.SeatRows*c.SeatColumns {
+			errs = append(errs, fmt.Errorf("SEAT_COUNT: %d conflicts with SEAT_ROWS x SEAT_COLUMNS = %d", c.SeatCount, c.SeatRows*c.SeatColumns))
+		} else {
+			c.SeatCount = c.SeatRows * c.SeatColumns
+		}
+	}
+	for _, sec := range c.Sections {
+		if sec.To > c.SeatCount {
+			errs = append(errs, fmt.Errorf("SEAT_SECTIONS: section %s ends at seat %d beyond SEAT_COUNT %d", sec.Name, sec.To, c.SeatCount))
+		}
+	}
+	if c.DBStatsInterval < 0 {
+		errs = append(errs, fmt.Errorf("DB_STATS_INTERVAL: must not be negative, got %v", c.DBStatsInterval))
+	}
+	if c.LogTimeFormat == "" {
+		errs = append(errs, errors.New("LOG_TIME_FORMAT: must not be empty"))
+	}
+	if c.ArtificialDelayMS < 0 {
+		errs = append(errs, fmt.Errorf("ARTIFICIAL_DELAY_MS: must not be negative, got %d", c.ArtificialDelayMS))
+	}
+	if _, err := parseIsolation(c.TxIsolation); err != nil {
+		errs = append(errs, fmt.Errorf("TX_ISOLATION: %w", err))
+	}
+	if c.DBPrewarm < 0 || c.DBPrewarm > c.MaxIdleConns {
+		// 유휴 한도를 넘는 연결은 반환 즉시 닫혀 예열 효과가 없다
+		errs = append(errs, fmt.Errorf("DB_PREWARM: must be between 0 and DB_MAX_IDLE_CONNS (%d), got %d", c.MaxIdleConns, c.DBPrewarm))
+	}
+	if c.ConflictRetryAfter < 0 {
+		errs = append(errs, fmt.Errorf("CONFLICT_RETRY_AFTER_SEC: must not be negative, got %d", c.ConflictRetryAfter))
+	}
+	if c.DBTimeout < 0 || c.DBReadTimeout < 0 || c.DBWriteTimeout < 0 {
+		errs = append(errs, fmt.Errorf("DB_TIMEOUT, DB_READ_TIMEOUT, DB_WRITE_TIMEOUT: must not be negative, got %v, %v, %v", c.DBTimeout, c.DBReadTimeout, c.DBWriteTimeout))
+	}
+	if c.MaxReservations < 0 {
+		errs = append(errs, fmt.Errorf("MAX_TOTAL_RESERVATIONS: must not be negative, got %d", c.MaxReservations))
+	}
+	if c.PerIPInflight < 0 {
+		errs = append(errs, fmt.Errorf("PER_IP_MAX_INFLIGHT: must not be negative, got %d", c.PerIPInflight))
+	}
+	if c.SeatCountReconcile < 0 {
+		errs = append(errs, fmt.Errorf("SEAT_COUNT_RECONCILE: must not be negative, got %v", c.SeatCountReconcile))
+	}
+	if c.PaymentLatency < 0 {
+		errs = append(errs, fmt.Errorf("PAYMENT_LATENCY: must not be negative, got %v", c.PaymentLatency))
+	}
+	if c.PaymentFailRate < 0 || c.PaymentFailRate > 1 {
+		errs = append(errs, fmt.Errorf("PAYMENT_FAIL_RATE: must be between 0.0 and 1.0, got %v", c.PaymentFailRate))
+	}
+	if _, err := parseLogFieldNames(c.LogFieldNames); err != nil {
+		errs = append(errs, fmt.Errorf("LOG_FIELD_NAMES: %w", err))
+	}
+
+	return c, errors.Join(errs...)
+}
+
+// MySQL 접속 문자열
+// 항상 parseTime=true 를 붙이고, 설정된 경우에만 아래 파라미터를 추가한다 (0/false 면 드라이버 기본값)
+//
+//	timeout           DB_TIMEOUT             연결 수립 제한 시간
+//	readTimeout       DB_READ_TIMEOUT        I/O 읽기 제한 시간
+//	writeTimeout      DB_WRITE_TIMEOUT       I/O 쓰기 제한 시간
+//	interpolateParams DB_INTERPOLATE_PARAMS  prepared statement 없이 클라이언트에서 인자 치환 (왕복 절감)
+func (c Config) DSN(host string) string {
+	m := mysql.NewConfig()
+	m.User = c.DBUser
+	m.Passwd = c.DBPassword
+	m.Net = "tcp"
+	m.Addr = net.JoinHostPort(host, strconv.Itoa(c.DBPort))
+	m.DBName = c.DBName
+	m.ParseTime = true
+	m.Timeout = c.DBTimeout
+	m.ReadTimeout = c.DBReadTimeout
+	m.WriteTimeout = c.DBWriteTimeout
+	m.InterpolateParams = c.DBInterpolateParams
+	return m.FormatDSN()
+}
+
+// 적용된 설정을 하나의 JSON 로그로 출력 (비밀 값 제외)
+func logConfig(c Config) {
+	data := marshalLogEntry(LogEntry{
+		Timestamp: formatLogTime(time.Now()),
+		Level:     "INFO",
+		Action:    "config",
+		Status:    "loaded",
+	})
+	// 로그 항목 객체 끝에 config 필드를 덧붙인다 (LOG_FIELD_NAMES 로 키를 바꿔도 같은 방식)
+	cfgJSON, _ := json.Marshal(c)
+	data = append(data[:len(data)-1], `,"config":`...)
+	data = append(append(data, cfgJSON...), '}')
+	log.Println(string(data))
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type ContiguousRequest struct {
+	UserID  int    `json:"user_id"`
+	Count   int    `json:"count"`
+	Section string `json:"section"` // 비어 있으면 전체 좌석에서 찾음
+}
+
+const maxContiguousCount = 20
+
+// 찾은 후보 블록을 다른 요청이 먼저 가져갔을 때 다시 찾는 최대 횟수 (다른 빈 블록이 남아 있으면 409 대신 그 블록으로)
+const maxContiguousAttempts = 3
+
+// 같은 행 안에서 연속된 빈 좌석 블록의 시작 좌석 ID 탐색 (gaps-and-islands)
+// 배치가 없으면 seat_row 가 모두 NULL 이라 전체가 한 행으로 취급된다
+// 잠금 없이 후보만 찾고, 실제 확인은 트랜잭션 안에서 다시 한다 (실패하면 maxContiguousAttempts 까지 다시 탐색)
+// %s 자리에는 구역 조건 (sectionCond) 이 들어간다
+const contiguousBlockQuery = `
+	SELECT MIN(seat_id) FROM (
+		SELECT seat_id, seat_row, seat_id - ROW_NUMBER() OVER (PARTITION BY seat_row ORDER BY seat_id) AS grp
+		FROM seats WHERE status = ?%s
+	) t
+	GROUP BY seat_row, grp
+	HAVING COUNT(*) >= ?
+	ORDER BY MIN(seat_id)
+	LIMIT 1`
+
+// 연속 좌석 예매 처리
+func reserveContiguousHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_contiguous", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req ContiguousRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_contiguous", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "reserve_contiguous", req.UserID, 0) {
+		return
+	}
+	if req.Count <= 0 || req.Count > maxContiguousCount {
+		http.Error(w, fmt.Sprintf("count must be between 1 and %d", maxContiguousCount), http.StatusBadRequest)
+		logJSON("WARN", "reserve_contiguous", req.UserID, 0, "bad_count", nil)
+		return
+	}
+	if req.Section != "" && !knownSection(req.Section) {
+		http.Error(w, "Unknown section", http.StatusBadRequest)
+		logJSON("WARN", "reserve_contiguous", req.UserID, 0, "unknown_section", nil)
+		return
+	}
+
+	// 구역이 지정되면 후보 탐색과 잠금 후 확인 모두 그 구역 안에서만
+	sectionCond, sectionArgs := "", []any{}
+	if req.Section != "" {
+		sectionCond, sectionArgs = " AND section = ?", []any{req.Section}
+	}
+
+	for attempt := 1; attempt <= maxContiguousAttempts; attempt++ {
+		var start int
+		err := db.QueryRow(fmt.Sprintf(contiguousBlockQuery, sectionCond), append(append([]any{SeatAvailable}, sectionArgs...), req.Count)...).Scan(&start)
+		if err == sql.ErrNoRows {
+			http.Error(w, localize(r, "No contiguous block available"), http.StatusConflict)
+			logJSON("INFO", "reserve_contiguous", req.UserID, 0, "no_block", nil)
+			return
+		} else if err != nil {
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			logJSON("ERROR", "reserve_contiguous", req.UserID, 0, "block_query_fail", err)
+			return
+		}
+		if !reserveContiguousBlock(w, r, req, start, sectionCond, sectionArgs, attempt == maxContiguousAttempts) {
+			return
+		}
+	}
+}
+
+// 후보 블록을 잠그고 여전히 전부 비어 있으면 예매
+// 그 사이 다른 요청이 블록 일부를 가져갔고 last 가 아니면 응답을 쓰지 않고 true (다시 탐색)
+func reserveContiguousBlock(w http.ResponseWriter, r *http.Request, req ContiguousRequest, start int, sectionCond string, sectionArgs []any, last bool) bool {
+	end := start + req.Count - 1
+
+	tx, err := beginReserveTx()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_contiguous", req.UserID, start, "tx_begin_fail", err)
+		return false
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_contiguous", req.UserID, start, "lock_timeout_set_fail", err)
+		return false
+	}
+
+	// 후보 블록 잠금 후 여전히 전부 비어 있는지 확인
+	rows, err := tx.Query(`SELECT seat_id, status FROM seats WHERE seat_id BETWEEN ? AND ?`+sectionCond+` FOR UPDATE`, append([]any{start, end}, sectionArgs...)...)
+	if isLockWaitTimeout(err) {
+		http.Error(w, localize(r, "Seat block is locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "reserve_contiguous", req.UserID, start, "lock_wait_timeout", err)
+		return false
+	} else if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_contiguous", req.UserID, start, "select_fail", err)
+		return false
+	}
+	var seatIDs []int
+	free := true
+	for rows.Next() {
+		var id int
+		var status SeatStatus
+		if err := rows.Scan(&id, &status); err != nil || status != SeatAvailable {
+			free = false
+			continue
+		}
+		seatIDs = append(seatIDs, id)
+	}
+	rows.Close()
+
+	if !free || len(seatIDs) != req.Count {
+		if !last {
+			logJSON("INFO", "reserve_contiguous", req.UserID, start, "block_taken_retry", nil)
+			return true
+		}
+		http.Error(w, localize(r, "Seat block already reserved"), http.StatusConflict)
+		logJSON("INFO", "reserve_contiguous", req.UserID, start, "seat_conflict", nil)
+		return false
+	}
+
+	if _, ok := checkReservationCap(w, tx, "reserve_contiguous", req.UserID, start, req.Count); !ok {
+		return false
+	}
+
+	reserved, err := reserveSeatsTx(tx, req.UserID, seatIDs)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_contiguous", req.UserID, start, stage, cause)
+		return false
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_contiguous", req.UserID, start, "commit_fail", err)
+		return false
+	}
+
+	logJSON("INFO", "reserve_contiguous", req.UserID, start, "success", nil)
+	afterReserve(req.UserID, seatIDs, reserved[0].ReservedAt)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "reserve_contiguous", req.UserID, start, map[string]any{
+		"message":            localize(r, "Reservation successful"),
+		"seat_ids":           seatIDs,
+		"confirmation_codes": confirmationCodes(reserved),
+		"reserved_at":        reserved[0].ReservedAt,
+	})
+	return false
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// 구역별 좌석 상태 집계 반환
+// 예: {"VIP":{"available":100,"reserved":400}}
+func sectionCountsHandler(w http.ResponseWriter, r *http.Request) {
+	rows, err := readDB.Query(`SELECT section, status, COUNT(*) FROM seats GROUP BY section, status`)
+	if isTableMissing(err) {
+		logJSON("ERROR", "section_counts", 0, 0, "not_initialized", err)
+		http.Error(w, "not_initialized", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		logJSON("ERROR", "section_counts", 0, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	counts := make(map[string]map[string]int)
+	for rows.Next() {
+		var section string
+		var status SeatStatus
+		var n int
+		if err := rows.Scan(&section, &status, &n); err != nil {
+			continue
+		}
+		if counts[section] == nil {
+			counts[section] = map[string]int{string(SeatAvailable): 0, string(SeatReserved): 0}
+		}
+		counts[section][string(status)] = n
+	}
+
+	logJSON("INFO", "section_counts", 0, 0, fmt.Sprintf("sections=%d", len(counts)), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "section_counts", 0, 0, counts)
+}
+
+// 전체 좌석 상태 집계 (부하 테스트 종료 후 재고 정합성 확인용)
+// 알 수 없는 상태의 행도 total 에는 포함되므로 상태별 합계와 total 이 다르면 이상 데이터가 있는 것이다
+// 메모리 집계가 켜져 있으면 (SEAT_COUNT_RECONCILE) SQL 없이 바로 답한다
+// 예: {"total":10000,"available":0,"reserved":9990,"disabled":10}
+func seatCountHandler(w http.ResponseWriter, r *http.Request) {
+	counts, ok := seatCounts.snapshot()
+	if !ok {
+		var err error
+		counts, err = store.Counts()
+		if isTableMissing(err) {
+			logJSON("ERROR", "seat_count", 0, 0, "not_initialized", err)
+			http.Error(w, "not_initialized", http.StatusServiceUnavailable)
+			return
+		} else if err != nil {
+			logJSON("ERROR", "seat_count", 0, 0, "query_fail", err)
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	logJSON("INFO", "seat_count", 0, 0, fmt.Sprintf("total=%d", counts["total"]), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "seat_count", 0, 0, counts)
+}
+
+// DB 에서 상태별 좌석 수 집계
+func querySeatCounts() (map[string]int, error) {
+	rows, err := readDB.Query(`SELECT status, COUNT(*) FROM seats GROUP BY status`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	counts := map[string]int{
+		"total":               0,
+		string(SeatAvailable): 0,
+		string(SeatReserved):  0,
+		string(SeatDisabled):  0,
+	}
+	for rows.Next() {
+		var status string
+		var n int
+		if err := rows.Scan(&status, &n); err != nil {
+			continue
+		}
+		counts["total"] += n
+		if SeatStatus(status).Valid() {
+			counts[status] = n
+		}
+	}
+	return counts, rows.Err()
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// 좌석별 도착 순서 (FIFO) 예매 큐 (SEAT_FIFO)
+// FOR UPDATE 잠금은 대기 중인 트랜잭션 중 누가 먼저 잡을지 보장하지 않으므로,
+// 같은 좌석 요청을 서버 안에서 줄 세워 먼저 도착한 요청부터 하나씩 DB 에 보낸다
+// 서버가 여러 대면 서버별 순서만 보장된다
+type seatQueues struct {
+	mu     sync.Mutex
+	queues map[int][]func() // seat_id → 대기 중인 작업 (맨 앞이 처리 중)
+}
+
+var fifoQueues = &seatQueues{queues: make(map[int][]func())}
+
+// seatID 큐 맨 뒤에 fn 을 넣고 실행이 끝날 때까지 대기
+// 큐가 비어 있던 좌석이면 이 좌석을 처리할 worker 를 새로 띄운다
+// fn 의 panic 은 worker 에서 잡아 호출한 쪽에서 다시 일으키므로 recoverPanic 이 처리하고 worker 는 큐를 계속 비운다
+func (q *seatQueues) do(seatID int, fn func()) {
+	done := make(chan any, 1) // fn 이 일으킨 panic 값 (없으면 nil)
+	queueDepth.fifoWaiting.Add(1)
+	job := func() {
+		defer func() { done <- recover() }()
+		queueDepth.fifoWaiting.Add(-1)
+		fn()
+	}
+
+	q.mu.Lock()
+	pending := q.queues[seatID]
+	q.queues[seatID] = append(pending, job)
+	if len(pending) == 0 {
+		go q.work(seatID)
+	}
+	q.mu.Unlock()
+
+	if rec := <-done; rec != nil {
+		panic(rec)
+	}
+}
+
+// 큐가 빌 때까지 도착 순서대로 실행하고 좌석 항목을 지움
+func (q *seatQueues) work(seatID int) {
+	for {
+		q.mu.Lock()
+		job := q.queues[seatID][0]
+		q.mu.Unlock()
+
+		job()
+
+		q.mu.Lock()
+		rest := q.queues[seatID][1:]
+		if len(rest) == 0 {
+			delete(q.queues, seatID)
+			q.mu.Unlock()
+			return
+		}
+		q.queues[seatID] = rest
+		q.mu.Unlock()
+	}
+}
+
+// 좌석 한 개 예매, SEAT_FIFO 가 켜져 있으면 같은 좌석 요청끼리 도착 순서대로 처리
+func reserveInOrder(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	if !cfg.SeatFIFO {
+		return store.Reserve(userID, seatID, nonce, operatorID, code, reservedAt)
+	}
+	var (
+		outcome reserveOutcome
+		seq     int64
+		err     error
+	)
+	fifoQueues.do(seatID, func() {
+		outcome, seq, err = store.Reserve(userID, seatID, nonce, operatorID, code, reservedAt)
+	})
+	return outcome, seq, err
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// 같은 좌석 작업은 넣은 순서대로 하나씩, 다른 좌석 작업은 따로 실행되는지 확인
+func TestSeatQueuesOrder(t *testing.T) {
+	q := &seatQueues{queues: make(map[int][]func())}
+
+	// 첫 작업이 끝나지 않게 막아 두고 뒤 작업들을 차례로 줄 세움
+	release := make(chan struct{})
+	var (
+		mu    sync.Mutex
+		order []int
+		wg    sync.WaitGroup
+	)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.do(1, func() { <-release })
+	}()
+	waitQueued(t, q, 1, 1)
+
+	const n = 20
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.do(1, func() {
+				mu.Lock()
+				order = append(order, i)
+				mu.Unlock()
+			})
+		}()
+		waitQueued(t, q, 1, i+2) // 다음 작업을 넣기 전에 이 작업이 큐에 들어갔는지 확인
+	}
+
+	// 다른 좌석은 막힌 좌석을 기다리지 않음
+	q.do(2, func() {})
+
+	close(release)
+	wg.Wait()
+	for i, got := range order {
+		if got != i {
+			t.Fatalf("order = %v, want 0..%d in order", order, n-1)
+		}
+	}
+	if len(order) != n {
+		t.Fatalf("ran %d jobs, want %d", len(order), n)
+	}
+	waitQueued(t, q, 1, 0) // 작업이 끝난 뒤 worker 가 좌석 항목을 지움
+}
+
+// 작업의 panic 이 호출한 쪽으로 전달되고 뒤 작업은 계속 처리되는지 확인
+func TestSeatQueuesPanic(t *testing.T) {
+	q := &seatQueues{queues: make(map[int][]func())}
+
+	func() {
+		defer func() {
+			if rec := recover(); rec != "boom" {
+				t.Fatalf("recovered %v, want boom", rec)
+			}
+		}()
+		q.do(1, func() { panic("boom") })
+	}()
+
+	ran := false
+	q.do(1, func() { ran = true })
+	if !ran {
+		t.Fatal("job after panic did not run")
+	}
+	waitQueued(t, q, 1, 0)
+}
+
+func waitQueued(t *testing.T, q *seatQueues, seatID, want int) {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		q.mu.Lock()
+		got := len(q.queues[seatID])
+		q.mu.Unlock()
+		if got == want {
+			return
+		}
+	}
+	t.Fatalf("seat %d queue did not reach %d jobs", seatID, want)
+}
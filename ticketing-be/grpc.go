@@ -0,0 +1,194 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	ticketingpb "ticketing-be/proto"
+)
+
+// HTTP 핸들러와 같은 좌석 연산을 쓰는 gRPC 서버 (GRPC_ADDR, 비어 있으면 띄우지 않음)
+// 응답 status 는 HTTP 로그의 status 와 같은 값이고, 토큰 검사 실패와 DB 오류 (Internal) 만 gRPC 오류로 돌려준다
+type ticketingServer struct {
+	ticketingpb.UnimplementedTicketingServer
+}
+
+// 예매 결과별 응답 status
+var reserveOutcomeStatus = map[reserveOutcome]string{
+	reserveOK:          "success",
+	reserveReplayed:    "replayed",
+	reserveNotFound:    "seat_not_found",
+	reserveLockTimeout: "lock_wait_timeout",
+	reserveDisabled:    "seat_disabled",
+	reserveSoldOut:     "sold_out",
+	reserveConflict:    "seat_conflict",
+	reserveNotOwned:    "seat_not_owned",
+}
+
+// 예매 결과를 HTTP 의 countReserveOutcome 과 같은 기준으로 집계
+func (srv ticketingServer) Reserve(ctx context.Context, req *ticketingpb.SeatRequest) (*ticketingpb.ReserveReply, error) {
+	queueDepth.reserveInflight.Add(1)
+	defer queueDepth.reserveInflight.Add(-1)
+
+	reply, err := srv.reserve(ctx, req)
+	switch {
+	case err != nil:
+		if c := status.Code(err); c == codes.Internal || c == codes.Unavailable {
+			reserveStats.errored.Add(1)
+		}
+	case reply.GetStatus() == "success":
+		reserveStats.succeeded.Add(1)
+	case reply.GetStatus() == "replayed":
+		reserveStats.replayed.Add(1)
+	case reply.GetStatus() != "seat_not_found":
+		reserveStats.conflicted.Add(1)
+	}
+	return reply, err
+}
+
+func (ticketingServer) reserve(ctx context.Context, req *ticketingpb.SeatRequest) (*ticketingpb.ReserveReply, error) {
+	userID, seatID := int(req.GetUserId()), int(req.GetSeatId())
+	if len(req.GetNonce()) > maxNonceLength {
+		logJSON("WARN", "grpc_reserve", userID, seatID, "bad_nonce", nil)
+		return nil, status.Errorf(codes.InvalidArgument, "nonce must be at most %d characters", maxNonceLength)
+	}
+	if err := authorizeGRPCUser(ctx, "grpc_reserve", userID, seatID); err != nil {
+		return nil, err
+	}
+
+	// 장애 주입 (카오스 테스트용)
+	if cfg.FaultInjectRate > 0 && rand.Float64() < cfg.FaultInjectRate {
+		logJSON("WARN", "grpc_reserve", userID, seatID, "injected_fault", nil)
+		return nil, status.Error(codes.Internal, "injected fault")
+	}
+
+	if !reserveBreaker.Allow() {
+		logJSON("WARN", "grpc_reserve", userID, seatID, "circuit_open", nil)
+		return nil, status.Error(codes.Unavailable, "service unavailable")
+	}
+
+	code := newConfirmationCode()
+	reservedAt := newReservedAt()
+	outcome, seq, err := reserveInOrder(userID, seatID, req.GetNonce(), 0, code, reservedAt)
+	reserveBreaker.Record(err)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		logJSON("ERROR", "grpc_reserve", userID, seatID, stage, cause)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	st := reserveOutcomeStatus[outcome]
+	logJSON("INFO", "grpc_reserve", userID, seatID, st, nil)
+	reply := &ticketingpb.ReserveReply{Status: st}
+	switch outcome {
+	case reserveOK:
+		afterReserve(userID, []int{seatID}, reservedAt)
+		reply.ReservationSeq = seq
+		reply.ConfirmationCode = formatConfirmationCode(code)
+		reply.ReservedAt = reservedAt.Format(time.RFC3339)
+	case reserveReplayed:
+		storedCode, storedAt := store.StoredReservation(seatID)
+		reply.ReservationSeq = seq
+		reply.ConfirmationCode = storedCode
+		reply.ReservedAt = storedAt.Format(time.RFC3339)
+	}
+	return reply, nil
+}
+
+func (ticketingServer) Cancel(ctx context.Context, req *ticketingpb.SeatRequest) (*ticketingpb.ReserveReply, error) {
+	userID, seatID := int(req.GetUserId()), int(req.GetSeatId())
+	if err := authorizeGRPCUser(ctx, "grpc_cancel", userID, seatID); err != nil {
+		return nil, err
+	}
+
+	outcome, err := store.Cancel(userID, seatID)
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		logJSON("ERROR", "grpc_cancel", userID, seatID, stage, cause)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	st := reserveOutcomeStatus[outcome]
+	logJSON("INFO", "grpc_cancel", userID, seatID, st, nil)
+	if outcome == reserveOK {
+		seatCounts.move(SeatReserved, SeatAvailable, 1)
+		invalidateSeatCache()
+	}
+	return &ticketingpb.ReserveReply{Status: st}, nil
+}
+
+// HTTP 의 authorizeUser 와 같은 검사, 토큰은 메타데이터 authorization 에서 읽는다
+func authorizeGRPCUser(ctx context.Context, action string, userID, seatID int) error {
+	var authorization string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if v := md.Get("authorization"); len(v) > 0 {
+			authorization = v[0]
+		}
+	}
+	st, err := checkUserToken(authorization, userID)
+	switch st {
+	case "":
+		return nil
+	case "user_mismatch":
+		logJSON("WARN", action, userID, seatID, st, err)
+		return status.Error(codes.PermissionDenied, "forbidden")
+	default:
+		logJSON("WARN", action, userID, seatID, st, err)
+		return status.Error(codes.Unauthenticated, "unauthorized")
+	}
+}
+
+func (ticketingServer) ListAvailable(ctx context.Context, _ *ticketingpb.ListAvailableRequest) (*ticketingpb.ListAvailableReply, error) {
+	seats, err := listAvailableSeats()
+	if err != nil {
+		logJSON("ERROR", "grpc_list_available", 0, 0, "query_fail", err)
+		return nil, status.Error(codes.Internal, "internal server error")
+	}
+
+	ids := make([]int32, len(seats))
+	for i, id := range seats {
+		ids[i] = int32(id)
+	}
+	return &ticketingpb.ListAvailableReply{SeatIds: ids}, nil
+}
+
+// GRPC_ADDR 에서 gRPC 서버 시작 (비어 있으면 nil)
+func startGRPCServer(addr string) (*grpc.Server, error) {
+	if addr == "" {
+		return nil, nil
+	}
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	srv := grpc.NewServer()
+	ticketingpb.RegisterTicketingServer(srv, ticketingServer{})
+	go func() {
+		if err := srv.Serve(lis); err != nil {
+			logJSON("ERROR", "grpc", 0, 0, "serve_fail", err)
+		}
+	}()
+	return srv, nil
+}
+
+// 진행 중인 호출을 마치고 종료, ctx 가 먼저 끝나면 남은 호출을 끊는다
+func stopGRPCServer(ctx context.Context, srv *grpc.Server) {
+	done := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-ctx.Done():
+		logJSON("WARN", "main", 0, 0, "grpc_shutdown_fail", ctx.Err())
+		srv.Stop()
+	}
+}
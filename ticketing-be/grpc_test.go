@@ -0,0 +1,107 @@
+package main
+
+import (
+	"context"
+	"net"
+	"slices"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	ticketingpb "ticketing-be/proto"
+)
+
+// gRPC 서버가 HTTP 와 같은 저장소를 거쳐 예매 → 충돌 → 취소를 처리하는지 확인
+func TestGRPCReserveFlow(t *testing.T) {
+	useMemStore(t, 10)
+
+	client := newTestGRPCClient(t)
+	ctx := context.Background()
+
+	const seatID = 3
+	steps := []struct {
+		name   string
+		call   func(context.Context, *ticketingpb.SeatRequest, ...grpc.CallOption) (*ticketingpb.ReserveReply, error)
+		userID int32
+		seatID int32
+		want   string
+		listed bool // 이후 빈 좌석 목록에 seatID 가 있는지
+	}{
+		{"reserve", client.Reserve, 1, seatID, "success", false},
+		{"conflict", client.Reserve, 2, seatID, "seat_conflict", false},
+		{"unknown seat", client.Reserve, 2, 99, "seat_not_found", false},
+		{"cancel by other user", client.Cancel, 2, seatID, "seat_not_owned", false},
+		{"cancel", client.Cancel, 1, seatID, "success", true},
+	}
+	for _, step := range steps {
+		reply, err := step.call(ctx, &ticketingpb.SeatRequest{UserId: step.userID, SeatId: step.seatID})
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if reply.GetStatus() != step.want {
+			t.Fatalf("%s: status %q, want %q", step.name, reply.GetStatus(), step.want)
+		}
+		if step.name == "reserve" && (reply.GetReservationSeq() == 0 || reply.GetConfirmationCode() == "" || reply.GetReservedAt() == "") {
+			t.Fatalf("%s: reply missing reservation fields: %v", step.name, reply)
+		}
+		list, err := client.ListAvailable(ctx, &ticketingpb.ListAvailableRequest{})
+		if err != nil {
+			t.Fatalf("%s: list: %v", step.name, err)
+		}
+		if got := slices.Contains(list.GetSeatIds(), seatID); got != step.listed {
+			t.Fatalf("%s: seat %d listed as available = %v", step.name, seatID, got)
+		}
+	}
+}
+
+// JWT_SECRET 이 있으면 gRPC 도 메타데이터 토큰의 sub 를 HTTP 와 같은 기준으로 확인하는지
+func TestGRPCReserveRequiresToken(t *testing.T) {
+	useMemStore(t, 10)
+	cfg.JWTSecret = "secret"
+
+	client := newTestGRPCClient(t)
+	steps := []struct {
+		name  string
+		token string
+		want  codes.Code
+	}{
+		{"no token", "", codes.Unauthenticated},
+		{"bad signature", signTestJWT(t, "other", 1), codes.Unauthenticated},
+		{"other user", signTestJWT(t, "secret", 2), codes.PermissionDenied},
+		{"owner", signTestJWT(t, "secret", 1), codes.OK},
+	}
+	for _, step := range steps {
+		ctx := context.Background()
+		if step.token != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+step.token)
+		}
+		_, err := client.Reserve(ctx, &ticketingpb.SeatRequest{UserId: 1, SeatId: 3})
+		if got := status.Code(err); got != step.want {
+			t.Fatalf("%s: code %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+// bufconn 위에 띄운 gRPC 서버의 클라이언트
+func newTestGRPCClient(t *testing.T) ticketingpb.TicketingClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	srv := grpc.NewServer()
+	ticketingpb.RegisterTicketingServer(srv, ticketingServer{})
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return ticketingpb.NewTicketingClient(conn)
+}
@@ -0,0 +1,25 @@
+package main
+
+import "net/http"
+
+// 서버 상태 확인
+// DB 에 닿지 않으면 503, 로그 파일 쓰기에 실패해 stderr 로 전환됐으면 200 이지만 status 가 degraded
+func healthHandler(w http.ResponseWriter, r *http.Request) {
+	code := http.StatusOK
+	resp := map[string]string{"status": "ok", "db": "ok", "log": "file"}
+
+	if logDegraded.Load() {
+		resp["status"] = "degraded"
+		resp["log"] = "stderr_fallback"
+	}
+	if err := db.PingContext(r.Context()); err != nil {
+		code = http.StatusServiceUnavailable
+		resp["status"] = "unavailable"
+		resp["db"] = "unreachable"
+		logJSON("ERROR", "healthz", 0, 0, "db_ping_fail", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	encodeJSON(w, "healthz", 0, 0, resp)
+}
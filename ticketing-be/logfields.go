@@ -0,0 +1,102 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// 로그 JSON 키 이름 바꾸기 (LOG_FIELD_NAMES)
+// 수집 시스템이 정해진 키를 요구할 때 변환 단계 없이 바로 넣을 수 있게 한다
+// "timestamp=@timestamp,level=log.level" 처럼 원래키=새키 목록, 또는 ecs (Elastic Common Schema 프리셋)
+// 키를 바꾼 로그는 ticketing-analysis 로 읽을 수 없다
+
+// LogEntry 의 JSON 키
+var logFieldKeys = []string{"timestamp", "level", "action", "user_id", "seat_id", "status", "error"}
+
+var ecsFieldNames = map[string]string{
+	"timestamp": "@timestamp",
+	"level":     "log.level",
+	"action":    "event.action",
+	"user_id":   "user.id",
+	"seat_id":   "labels.seat_id",
+	"status":    "labels.status",
+	"error":     "error.message",
+}
+
+// 적용 중인 키 이름 (nil 이면 기본 키)
+var logFieldNames map[string]string
+
+func parseLogFieldNames(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	switch s {
+	case "":
+		return nil, nil
+	case "ecs":
+		return ecsFieldNames, nil
+	}
+
+	names := make(map[string]string)
+	used := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		from, to, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok || to == "" {
+			return nil, fmt.Errorf("%q is not FIELD=NAME", part)
+		}
+		if !slices.Contains(logFieldKeys, from) {
+			return nil, fmt.Errorf("unknown field %q (want one of %s)", from, strings.Join(logFieldKeys, ", "))
+		}
+		if used[to] {
+			return nil, fmt.Errorf("name %q used twice", to)
+		}
+		names[from] = to
+		used[to] = true
+	}
+	return names, nil
+}
+
+// LogEntry 를 JSON 으로 (키 이름을 바꾸지 않으면 encoding/json 그대로)
+func marshalLogEntry(e LogEntry) []byte {
+	if logFieldNames == nil {
+		data, _ := json.Marshal(e)
+		return data
+	}
+
+	// 기본 형식의 omitempty 와 같게 빈 값은 생략
+	fields := []struct {
+		key  string
+		val  any
+		omit bool
+	}{
+		{"timestamp", e.Timestamp, false},
+		{"level", e.Level, false},
+		{"action", e.Action, false},
+		{"user_id", e.UserID, e.UserID == 0},
+		{"seat_id", e.SeatID, e.SeatID == 0},
+		{"status", e.Status, e.Status == ""},
+		{"error", e.Error, e.Error == ""},
+	}
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for _, f := range fields {
+		if f.omit {
+			continue
+		}
+		name := f.key
+		if renamed, ok := logFieldNames[f.key]; ok {
+			name = renamed
+		}
+		if b.Len() > 1 {
+			b.WriteByte(',')
+		}
+		k, _ := json.Marshal(name)
+		v, _ := json.Marshal(f.val)
+		b.Write(k)
+		b.WriteByte(':')
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes()
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"math"
+	"math/rand/v2"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 // JSON 형식 로그 구조체
@@ -25,19 +34,49 @@ type LogEntry struct {
 }
 
 type TicketRequest struct {
-	UserID int `json:"user_id"`
-	SeatID int `json:"seat_id"`
+	UserID int    `json:"user_id"`
+	SeatID int    `json:"seat_id"`
+	Nonce  string `json:"nonce,omitempty"` // 재시도 시 같은 값을 보내면 한 번만 예매됨
 }
 
+const maxNonceLength = 64
+
 var db *sql.DB
+var readDB *sql.DB // 읽기 전용 조회용 (복제본 미설정 시 db 와 동일)
+
+// 빈 좌석 목록 캐시 (HTTP, gRPC 핸들러가 동시에 읽고 무효화한다)
+var seatCache struct {
+	mu    sync.Mutex
+	seats []int
+	valid bool
+	gen   uint64 // 무효화 횟수, 조회 도중 무효화되면 조회 결과를 캐시하지 않는다
+}
+
+// LOG_TIME_FORMAT 에 따른 로그 시각 문자열
+// rfc3339 (기본), rfc3339nano, unixms (epoch 밀리초), 그 외는 Go 시간 레이아웃으로 취급
+func formatLogTime(t time.Time) string {
+	switch cfg.LogTimeFormat {
+	case "", "rfc3339":
+		return t.Format(time.RFC3339)
+	case "rfc3339nano":
+		return t.Format(time.RFC3339Nano)
+	case "unixms":
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	default:
+		return t.Format(cfg.LogTimeFormat)
+	}
+}
 
-var cachedSeats []int
-var isCached bool
+// 로그 파일 쓰기가 연속으로 이만큼 실패하면 stderr 로 전환
+const logFailThreshold = 3
+
+var logWriteFailures atomic.Int32
+var logDegraded atomic.Bool // stderr 로 전환됨 (/healthz 에 표시)
 
 // JSON 로그 출력 함수
 func logJSON(level, action string, userID, seatID int, status string, err error) {
 	entry := LogEntry{
-		Timestamp: time.Now().Format(time.RFC3339),
+		Timestamp: formatLogTime(time.Now()),
 		Level:     level,
 		Action:    action,
 		UserID:    userID,
@@ -47,23 +86,65 @@ func logJSON(level, action string, userID, seatID int, status string, err error)
 	if err != nil {
 		entry.Error = err.Error()
 	}
-	data, _ := json.Marshal(entry)
-	log.Println(string(data))
+	data := marshalLogEntry(entry)
+	if werr := log.Output(2, string(data)); werr != nil {
+		// 디스크가 가득 차는 등 쓰기 실패: 이번 항목은 stderr 로라도 남긴다
+		fmt.Fprintln(os.Stderr, string(data))
+		if logWriteFailures.Add(1) >= logFailThreshold && logDegraded.CompareAndSwap(false, true) {
+			log.SetOutput(os.Stderr)
+			logJSON("ERROR", "log", 0, 0, "fallback_stderr", werr)
+		}
+		return
+	}
+	logWriteFailures.Store(0)
 }
 
-// 좌석 리스트 반환
-func availableSeatsHandler(w http.ResponseWriter, r *http.Request) {
-	if isCached {
-		logJSON("INFO", "available_seats", 0, 0, fmt.Sprintf("count=%d", len(cachedSeats)), nil)
-		w.Header().Set("Content-Type", "application/json")
-		json.NewEncoder(w).Encode(cachedSeats)
-		return
+// JSON 응답 인코딩 (헤더 전송 후 실패하면 응답은 이미 깨졌으므로 로그만 남김)
+func encodeJSON(w http.ResponseWriter, action string, userID, seatID int, v any) {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logJSON("ERROR", action, userID, seatID, "encode_fail", err)
+	}
+}
+
+// 빈 좌석 ID 목록 조회 (캐시가 있으면 캐시 사용)
+func listAvailableSeats() ([]int, error) {
+	seatCache.mu.Lock()
+	if seatCache.valid {
+		seats := seatCache.seats
+		seatCache.mu.Unlock()
+		return seats, nil
 	}
-	rows, err := db.Query(`SELECT seat_id FROM seats WHERE status = 'available' ORDER BY seat_id`)
+	gen := seatCache.gen
+	seatCache.mu.Unlock()
+
+	seats, err := store.AvailableSeats()
 	if err != nil {
-		logJSON("ERROR", "available_seats", 0, 0, "query_fail", err)
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		return
+		return nil, err
+	}
+
+	seatCache.mu.Lock()
+	if seatCache.gen == gen {
+		seatCache.seats = seats
+		seatCache.valid = true
+	}
+	seatCache.mu.Unlock()
+	return seats, nil
+}
+
+// 좌석 상태가 바뀐 뒤 빈 좌석 캐시 무효화
+func invalidateSeatCache() {
+	seatCache.mu.Lock()
+	seatCache.seats = nil
+	seatCache.valid = false
+	seatCache.gen++
+	seatCache.mu.Unlock()
+}
+
+// DB 에서 빈 좌석 ID 목록 조회
+func queryAvailableSeats() ([]int, error) {
+	rows, err := readDB.Query(`SELECT seat_id FROM seats WHERE status = ? ORDER BY seat_id`, SeatAvailable)
+	if err != nil {
+		return nil, err
 	}
 	defer rows.Close()
 
@@ -74,12 +155,127 @@ func availableSeatsHandler(w http.ResponseWriter, r *http.Request) {
 			seats = append(seats, id)
 		}
 	}
+	return seats, nil
+}
+
+// seat_id 범위 안의 빈 좌석 ID 목록 조회 (부분 조회라 캐시하지 않음)
+func listAvailableSeatsInRange(from, to int) ([]int, error) {
+	rows, err := readDB.Query(`SELECT seat_id FROM seats WHERE status = ? AND seat_id BETWEEN ? AND ? ORDER BY seat_id`, SeatAvailable, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	seats := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err == nil {
+			seats = append(seats, id)
+		}
+	}
+	return seats, nil
+}
+
+// ?from=&to= 파라미터 파싱, 둘 다 없으면 ok 만 true 이고 ranged 는 false
+func parseSeatRange(r *http.Request) (from, to int, ranged, ok bool) {
+	q := r.URL.Query()
+	if !q.Has("from") && !q.Has("to") {
+		return 0, 0, false, true
+	}
+	from, to = 1, math.MaxInt32
+	var err error
+	if v := q.Get("from"); v != "" {
+		if from, err = strconv.Atoi(v); err != nil {
+			return 0, 0, true, false
+		}
+	}
+	if v := q.Get("to"); v != "" {
+		if to, err = strconv.Atoi(v); err != nil {
+			return 0, 0, true, false
+		}
+	}
+	return from, to, true, from <= to
+}
+
+// 좌석 목록에서 n 개를 무작위로 골라 새 슬라이스로 반환 (캐시된 원본은 건드리지 않음)
+func sampleSeats(seats []int, n int) []int {
+	if n >= len(seats) {
+		return seats
+	}
+	sample := make([]int, n)
+	for i, j := range rand.Perm(len(seats))[:n] {
+		sample[i] = seats[j]
+	}
+	return sample
+}
+
+// 좌석 리스트 반환 (?from=&to= 로 범위, ?section=NAME 으로 구역 지정 가능, ?sample=N 이면 그중 N 개만 무작위로)
+// 기본은 JSON 배열, ?format=bitmap 이면 좌석당 1 비트 비트맵 (형식은 bitmap.go)
+func availableSeatsHandler(w http.ResponseWriter, r *http.Request) {
+	from, to, ranged, ok := parseSeatRange(r)
+	if !ok {
+		http.Error(w, "Invalid from or to", http.StatusBadRequest)
+		logJSON("WARN", "available_seats", 0, 0, "bad_range", nil)
+		return
+	}
+	sample := 0
+	if v := r.URL.Query().Get("sample"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 {
+			http.Error(w, "Invalid sample", http.StatusBadRequest)
+			logJSON("WARN", "available_seats", 0, 0, "bad_sample", nil)
+			return
+		}
+		sample = n
+	}
+	section := r.URL.Query().Get("section")
+	if section != "" && !knownSection(section) {
+		http.Error(w, "Unknown section", http.StatusBadRequest)
+		logJSON("WARN", "available_seats", 0, 0, "unknown_section", nil)
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format != "" && format != "json" && format != "bitmap" {
+		http.Error(w, "format must be json or bitmap", http.StatusBadRequest)
+		logJSON("WARN", "available_seats", 0, 0, "bad_format", nil)
+		return
+	}
+
+	var seats []int
+	var err error
+	if ranged {
+		seats, err = store.AvailableSeatsInRange(from, to)
+	} else {
+		seats, err = listAvailableSeats()
+	}
+	if isTableMissing(err) {
+		logJSON("ERROR", "available_seats", 0, 0, "not_initialized", err)
+		http.Error(w, "not_initialized", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		logJSON("ERROR", "available_seats", 0, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if section != "" {
+		seats = seatsInSection(seats, section) // 개수도 구역 기준
+	}
 
 	logJSON("INFO", "available_seats", 0, 0, fmt.Sprintf("count=%d", len(seats)), nil)
+	w.Header().Set("X-Available-Count", strconv.Itoa(len(seats)))
+	if r.Method == http.MethodHead {
+		return // 개수만 필요한 폴링용, 본문 직렬화 생략
+	}
+	if sample > 0 {
+		seats = sampleSeats(seats, sample) // X-Available-Count 는 전체 개수 그대로
+	}
+	if format == "bitmap" {
+		writeSeatBitmap(w, seats, from, to, ranged)
+		return
+	}
 	w.Header().Set("Content-Type", "application/json")
-	cachedSeats = seats
-	isCached = true
-	json.NewEncoder(w).Encode(seats)
+	encodeJSON(w, "available_seats", 0, 0, seats)
 }
 
 // 좌석 예매 처리
@@ -96,119 +292,439 @@ func reserveHandler(w http.ResponseWriter, r *http.Request) {
 		logJSON("ERROR", "reserve", 0, 0, "invalid_json", err)
 		return
 	}
-
-	tx, err := db.Begin()
-	if err != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		logJSON("ERROR", "reserve", req.UserID, req.SeatID, "tx_begin_fail", err)
+	if len(req.Nonce) > maxNonceLength {
+		http.Error(w, fmt.Sprintf("nonce must be at most %d characters", maxNonceLength), http.StatusBadRequest)
+		logJSON("WARN", "reserve", req.UserID, req.SeatID, "bad_nonce", nil)
 		return
 	}
-	defer tx.Rollback()
 
-	var status string
-	err = tx.QueryRow(`SELECT status FROM seats WHERE seat_id = ? FOR UPDATE`, req.SeatID).Scan(&status)
-	if err == sql.ErrNoRows {
-		http.Error(w, "Seat not found", http.StatusNotFound)
-		logJSON("WARN", "reserve", req.UserID, req.SeatID, "seat_not_found", nil)
+	// JWT_SECRET 이 있으면 토큰의 sub 와 user_id 가 같아야 한다
+	if !authorizeUser(w, r, "reserve", req.UserID, req.SeatID) {
 		return
-	} else if err != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		logJSON("ERROR", "reserve", req.UserID, req.SeatID, "select_fail", err)
+	}
+
+	// 장애 주입 (카오스 테스트용)
+	if cfg.FaultInjectRate > 0 && rand.Float64() < cfg.FaultInjectRate {
+		http.Error(w, "injected fault", http.StatusInternalServerError)
+		logJSON("WARN", "reserve", req.UserID, req.SeatID, "injected_fault", nil)
 		return
 	}
 
-	if status != "available" {
-		http.Error(w, "Seat already reserved", http.StatusConflict)
-		logJSON("INFO", "reserve", req.UserID, req.SeatID, "seat_conflict", nil)
+	reserveAndRespond(w, r, "reserve", req, 0)
+}
+
+// 좌석 한 개 예매 후 결과에 맞는 응답 작성 (/reserve 와 /reserve/operator 공용)
+// operatorID 가 0 이 아니면 대신 예매한 직원으로 함께 기록한다
+func reserveAndRespond(w http.ResponseWriter, r *http.Request, action string, req TicketRequest, operatorID int) {
+	// DB 장애 시 빠른 실패
+	if !reserveBreaker.Allow() {
+		http.Error(w, "Service unavailable", http.StatusServiceUnavailable)
+		logJSON("WARN", action, req.UserID, req.SeatID, "circuit_open", nil)
 		return
 	}
+	var dbErr error
+	defer func() { reserveBreaker.Record(dbErr) }()
 
-	_, err = tx.Exec(`UPDATE seats SET status = 'reserved', user_id = ? WHERE seat_id = ?`, req.UserID, req.SeatID)
+	code := newConfirmationCode()
+	reservedAt := newReservedAt()
+	outcome, seq, err := reserveInOrder(req.UserID, req.SeatID, req.Nonce, operatorID, code, reservedAt)
 	if err != nil {
+		dbErr = err
+		stage, cause := splitReserveError(err)
 		http.Error(w, "internal server error", http.StatusInternalServerError)
-		logJSON("ERROR", "reserve", req.UserID, req.SeatID, "update_fail", err)
+		logJSON("ERROR", action, req.UserID, req.SeatID, stage, cause)
 		return
 	}
 
-	if err := tx.Commit(); err != nil {
-		http.Error(w, "internal server error", http.StatusInternalServerError)
-		logJSON("ERROR", "reserve", req.UserID, req.SeatID, "commit_fail", err)
+	switch outcome {
+	case reserveReplayed:
+		// 이전 요청이 이미 성공함: 같은 성공 응답을 다시 보냄
+		logJSON("INFO", action, req.UserID, req.SeatID, "replayed", nil)
+		markReplayed(w)
+		storedCode, storedAt := store.StoredReservation(req.SeatID)
+		w.Header().Set("Content-Type", "application/json")
+		encodeJSON(w, action, req.UserID, req.SeatID, map[string]any{
+			"message":           localize(r, "Reservation successful"),
+			"reservation_seq":   seq,
+			"confirmation_code": storedCode,
+			"reserved_at":       storedAt,
+			"replayed":          true,
+		})
+		return
+	case reserveNotFound:
+		http.Error(w, localize(r, "Seat not found"), http.StatusNotFound)
+		logJSON("WARN", action, req.UserID, req.SeatID, "seat_not_found", nil)
+		return
+	case reserveLockTimeout:
+		// 다른 트랜잭션이 좌석을 잡고 있음: 충돌로 처리
+		setRetryAfter(w)
+		http.Error(w, localize(r, "Seat is locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", action, req.UserID, req.SeatID, "lock_wait_timeout", nil)
+		return
+	case reserveDisabled:
+		http.Error(w, localize(r, "Seat is not for sale"), http.StatusConflict)
+		logJSON("INFO", action, req.UserID, req.SeatID, "seat_disabled", nil)
+		return
+	case reserveSoldOut:
+		http.Error(w, "sold_out", http.StatusGone)
+		logJSON("INFO", action, req.UserID, req.SeatID, "sold_out", nil)
+		return
+	case reserveConflict:
+		setRetryAfter(w)
+		logJSON("INFO", action, req.UserID, req.SeatID, "seat_conflict", nil)
+		if r.URL.Query().Get("suggest") == "true" {
+			writeConflictWithSuggestions(w, r, action, req.UserID, req.SeatID)
+			return
+		}
+		if cfg.ConflictShowOwner {
+			writeConflictWithOwner(w, req.UserID, req.SeatID)
+			return
+		}
+		http.Error(w, localize(r, "Seat already reserved"), http.StatusConflict)
 		return
 	}
 
-	logJSON("INFO", "reserve", req.UserID, req.SeatID, "success", nil)
+	logJSON("INFO", action, req.UserID, req.SeatID, "success", nil)
+	afterReserve(req.UserID, []int{req.SeatID}, reservedAt)
 	w.Header().Set("Content-Type", "application/json")
-	cachedSeats = nil // 캐시 초기화
-	isCached = false  // 캐시 무효화
-	json.NewEncoder(w).Encode(map[string]string{
-		"message": "Reservation successful",
+	encodeJSON(w, action, req.UserID, req.SeatID, map[string]any{
+		"message":           localize(r, "Reservation successful"),
+		"reservation_seq":   seq,
+		"confirmation_code": formatConfirmationCode(code),
+		"reserved_at":       reservedAt,
 	})
 }
 
+// 디버그용 충돌 응답: 좌석을 먼저 가져간 사용자를 함께 알려준다 (CONFLICT_SHOW_OWNER)
+// 다른 사용자 정보가 노출되므로 운영 환경에서는 켜지 않는다
+func writeConflictWithOwner(w http.ResponseWriter, userID, seatID int) {
+	var owner sql.NullInt64
+	if err := db.QueryRow(`SELECT user_id FROM seats WHERE seat_id = ?`, seatID).Scan(&owner); err != nil {
+		logJSON("WARN", "reserve", userID, seatID, "owner_lookup_fail", err)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	encodeJSON(w, "reserve", userID, seatID, map[string]any{
+		"error":         "Seat already reserved",
+		"owner_user_id": owner.Int64,
+	})
+}
+
+// 예매 충돌 응답에 재시도 힌트 추가 (CONFLICT_RETRY_AFTER_SEC, 0 이면 생략)
+// 좌석이 언제 풀릴지 알 수 있는 정보(선점 만료 등)가 없어 설정값을 그대로 쓰는 best-effort 힌트다
+func setRetryAfter(w http.ResponseWriter) {
+	if cfg.ConflictRetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(cfg.ConflictRetryAfter))
+	}
+}
+
+// 트랜잭션 세션의 행 잠금 대기 시간 설정 (0 이면 서버 기본값 유지)
+func setLockWaitTimeout(tx *sql.Tx) error {
+	if cfg.LockWaitTimeout <= 0 {
+		return nil
+	}
+	_, err := tx.Exec(fmt.Sprintf("SET SESSION innodb_lock_wait_timeout = %d", cfg.LockWaitTimeout))
+	return err
+}
+
+// MySQL 1146: Table doesn't exist (initSeats 가 돌지 않았거나 테이블이 지워짐)
+func isTableMissing(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == 1146
+}
+
+// MySQL 1205: Lock wait timeout exceeded
+func isLockWaitTimeout(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == 1205
+}
+
 // 좌석 테이블 생성 및 초기화
 func initSeats(total int) error {
 	_, err := db.Exec(`
 		CREATE TABLE IF NOT EXISTS seats (
 			seat_id INT PRIMARY KEY,
 			status VARCHAR(20) NOT NULL DEFAULT 'available',
-			user_id INT
+			user_id INT,
+			reserved_at DATETIME,
+			seat_row INT,
+			seat_col INT,
+			label VARCHAR(16),
+			section VARCHAR(32) NOT NULL DEFAULT 'general',
+			nonce VARCHAR(64) UNIQUE,
+			reservation_seq BIGINT,
+			price INT NOT NULL DEFAULT 0,
+			operator_id INT,
+			confirmation_code CHAR(9) UNIQUE,
+			INDEX idx_user_status (user_id, status)
 		)
 	`)
 	if err != nil {
 		logJSON("ERROR", "init_seats", 0, 0, "create_table_fail", err)
 		return err
 	}
+	backfillLayout, err := migrateSeatsTable()
+	if err != nil {
+		return err
+	}
+
+	// 예매 순번 발급용 테이블
+	_, err = db.Exec(`
+		CREATE TABLE IF NOT EXISTS reservation_sequence (
+			seq BIGINT AUTO_INCREMENT PRIMARY KEY,
+			seat_id INT NOT NULL,
+			user_id INT NOT NULL
+		)
+	`)
+	if err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "create_sequence_table_fail", err)
+		return err
+	}
+
+	if err := setupReservationCap(); err != nil {
+		return err
+	}
 
-	for i := 1; i <= total; i++ {
-		_, err := db.Exec(`INSERT IGNORE INTO seats (seat_id) VALUES (?)`, i)
+	if err := setupAuditTrigger(cfg.AuditTrigger); err != nil {
+		return err
+	}
+
+	// 여러 행을 한 번에 INSERT 하여 왕복 횟수 절감
+	for from := 1; from <= total; from += cfg.SeatInitBatch {
+		to := min(from+cfg.SeatInitBatch-1, total)
+		placeholders := make([]string, 0, to-from+1)
+		args := make([]any, 0, 6*(to-from+1))
+		for i := from; i <= to; i++ {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+			if row, col, label, ok := seatPosition(i); ok {
+				args = append(args, i, row, col, label, sectionFor(i), priceFor(i))
+			} else {
+				args = append(args, i, nil, nil, nil, sectionFor(i), priceFor(i))
+			}
+		}
+
+		query := `INSERT IGNORE INTO seats (seat_id, seat_row, seat_col, label, section, price) VALUES ` + strings.Join(placeholders, ",")
+		if backfillLayout {
+			// 방금 추가한 배치 컬럼을 기존 행에도 채운다 (예매 상태는 건드리지 않음)
+			query = `INSERT INTO seats (seat_id, seat_row, seat_col, label, section, price) VALUES ` + strings.Join(placeholders, ",") +
+				` ON DUPLICATE KEY UPDATE seat_row = VALUES(seat_row), seat_col = VALUES(seat_col), label = VALUES(label), section = VALUES(section), price = VALUES(price)`
+		}
+		_, err := db.Exec(query, args...)
 		if err != nil {
-			logJSON("WARN", "init_seats", 0, i, "insert_ignore_fail", err)
+			logJSON("WARN", "init_seats", 0, from, "insert_ignore_fail", err)
 		}
+		logJSON("INFO", "init_seats", 0, 0, fmt.Sprintf("progress=%d/%d", to, total), nil)
 	}
 
 	logJSON("INFO", "init_seats", 0, 0, fmt.Sprintf("inserted_up_to=%d", total), nil)
 	return nil
 }
 
+// DB 연결 풀 생성
+func openDB(host string) (*sql.DB, error) {
+	conn, err := sql.Open("mysql", cfg.DSN(host))
+	if err != nil {
+		return nil, err
+	}
+	conn.SetMaxOpenConns(cfg.MaxOpenConns)
+	conn.SetMaxIdleConns(cfg.MaxIdleConns)
+	conn.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+	return conn, nil
+}
+
+// DB 가 응답할 때까지 대기
+func waitForDB(conn *sql.DB, status string) {
+	for {
+		if err := conn.Ping(); err != nil {
+			logJSON("WARN", "main", 0, 0, status, err)
+			time.Sleep(5 * time.Second)
+		} else {
+			break
+		}
+	}
+}
+
+// 트래픽을 받기 전에 연결 n 개를 미리 열어 둠 (첫 요청 폭주 때 연결 수립 비용 제거)
+// 1, 2, 4, ... 개씩 늘려 가며 동시에 Ping 해 DB 에 한 번에 몰리지 않게 하고
+// 모두 열린 뒤 한꺼번에 반환해 유휴 연결로 남긴다
+func prewarmPool(conn *sql.DB, n int) {
+	if n <= 0 {
+		return
+	}
+	start := time.Now()
+	ctx := context.Background()
+	conns := make([]*sql.Conn, 0, n)
+	defer func() {
+		for _, c := range conns {
+			c.Close()
+		}
+	}()
+
+	for step := 1; len(conns) < n; step *= 2 {
+		batch := min(step, n-len(conns))
+		opened := make(chan *sql.Conn, batch)
+		var wg sync.WaitGroup
+		for i := 0; i < batch; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				c, err := conn.Conn(ctx)
+				if err == nil {
+					err = c.PingContext(ctx)
+				}
+				if err != nil {
+					logJSON("WARN", "db_prewarm", 0, 0, "conn_fail", err)
+					if c != nil {
+						c.Close()
+					}
+					return
+				}
+				opened <- c
+			}()
+		}
+		wg.Wait()
+		close(opened)
+		before := len(conns)
+		for c := range opened {
+			conns = append(conns, c)
+		}
+		if len(conns) == before {
+			break // 이번 단계에서 하나도 못 열면 중단
+		}
+	}
+
+	logJSON("INFO", "db_prewarm", 0, 0, fmt.Sprintf("conns=%d elapsed=%s", len(conns), time.Since(start)), nil)
+}
+
+// 라우팅 등록 (main 과 통합 테스트 공용)
+func newMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", indexHandler)
+	mux.HandleFunc("/seats/available", availableSeatsHandler)
+	mux.HandleFunc("/seats/map", seatMapHandler)
+	mux.HandleFunc("/seats/count", seatCountHandler)
+	mux.HandleFunc("/seats/count/by-section", sectionCountsHandler)
+	mux.HandleFunc("/reserve", countReserveOutcome(reserveHandler))
+	mux.HandleFunc("/reserve/contiguous", countReserveOutcome(reserveContiguousHandler))
+	mux.HandleFunc("/reserve/batch", countReserveOutcome(reserveBatchHandler))
+	mux.HandleFunc("/reserve/any", countReserveOutcome(reserveAnyHandler))
+	mux.HandleFunc("/reserve/preferred", countReserveOutcome(reservePreferredHandler))
+	mux.HandleFunc("/reserve/pay", countReserveOutcome(reservePayHandler))
+	mux.HandleFunc("/reserve/verify", verifyReservationHandler)
+	mux.HandleFunc("/reserve/operator", requireAdmin(countReserveOutcome(reserveOperatorHandler)))
+	mux.HandleFunc("/reservations", userReservationsHandler)
+	mux.HandleFunc("/reserve/cancel", cancelHandler)
+	mux.HandleFunc("/reserve/cancel-all", cancelAllHandler)
+	mux.HandleFunc("/metrics", metricsHandler)
+	mux.HandleFunc("/healthz", healthHandler)
+	mux.HandleFunc("/admin/reservations", requireAdmin(adminReservationsHandler))
+	mux.HandleFunc("/admin/leaderboard", requireAdmin(adminLeaderboardHandler))
+	mux.HandleFunc("/admin/seat", requireAdmin(adminSeatHandler))
+	mux.HandleFunc("/admin/seats/disable", requireAdmin(adminSetSeatsHandler(SeatAvailable, SeatDisabled)))
+	mux.HandleFunc("/admin/seats/enable", requireAdmin(adminSetSeatsHandler(SeatDisabled, SeatAvailable)))
+	mux.HandleFunc("/admin/config", requireAdmin(adminConfigHandler))
+	mux.HandleFunc("/admin/seats/reset", requireAdmin(adminResetHandler))
+	return mux
+}
+
 func main() {
 	var err error
 
-	logFile, err := os.OpenFile(fmt.Sprintf("/results/ticketing-%s.log", time.Now().Format("20060102150405")), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	cfg, err = loadConfig()
+	if err != nil {
+		fmt.Printf("Invalid configuration:\n%v\n", err)
+		os.Exit(1)
+	}
+
+	logFile, err := os.OpenFile(fmt.Sprintf("%s/ticketing-%s.log", cfg.LogDir, time.Now().Format("20060102150405")), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
 	if err != nil {
 		fmt.Printf("Failed to open log file: %v\n", err)
 		os.Exit(1)
 	}
 	log.SetOutput(logFile)
+	logFieldNames, _ = parseLogFieldNames(cfg.LogFieldNames) // loadConfig 에서 검증됨
+	logConfig(cfg)
 
-	dsn := "root:password@tcp(db:3306)/ticketing"
-	db, err = sql.Open("mysql", dsn)
+	if err := loadMessages(cfg.MessagesFile); err != nil {
+		logJSON("FATAL", "main", 0, 0, "messages_load_fail", err)
+		log.Fatalf("Failed to load messages: %v", err)
+	}
+
+	db, err = openDB(cfg.DBHost)
 	if err != nil {
 		logJSON("FATAL", "main", 0, 0, "db_open_fail", err)
 		log.Fatalf("Failed to open DB: %v", err)
 	}
+	waitForDB(db, "db_not_reachable")
+	logJSON("INFO", "main", 0, 0, "db_connected", nil)
 
-	db.SetMaxOpenConns(5000)
-	db.SetMaxIdleConns(100)
-	db.SetConnMaxLifetime(30 * time.Second)
-
-	for {
-		if err = db.Ping(); err != nil {
-			logJSON("WARN", "main", 0, 0, "db_not_reachable", err)
-			time.Sleep(5 * time.Second)
-		} else {
-			break
+	readDB = db
+	if cfg.DBReadHost != "" {
+		readDB, err = openDB(cfg.DBReadHost)
+		if err != nil {
+			logJSON("FATAL", "main", 0, 0, "read_db_open_fail", err)
+			log.Fatalf("Failed to open read DB: %v", err)
 		}
+		waitForDB(readDB, "read_db_not_reachable")
+		logJSON("INFO", "main", 0, 0, "read_db_connected", nil)
 	}
-	logJSON("INFO", "main", 0, 0, "db_connected", nil)
 
-	if err := initSeats(10000); err != nil {
+	if err := initSeats(cfg.SeatCount); err != nil {
 		logJSON("FATAL", "main", 0, 0, "seat_init_fail", err)
 		log.Fatalf("Seat initialization failed: %v", err)
 	}
 
-	http.HandleFunc("/seats/available", availableSeatsHandler)
-	http.HandleFunc("/reserve", reserveHandler)
+	// 스키마, 좌석 수 점검 (SELF_CHECK_STRICT 이면 불일치 시 기동 중단)
+	if err := selfCheck(); err != nil {
+		if cfg.SelfCheckStrict {
+			logJSON("FATAL", "self_check", 0, 0, "mismatch", err)
+			log.Fatalf("Self check failed: %v", err)
+		}
+		logJSON("WARN", "self_check", 0, 0, "mismatch", err)
+	} else {
+		logJSON("INFO", "self_check", 0, 0, "ok", nil)
+	}
+
+	prewarmPool(db, cfg.DBPrewarm)
+
+	reserveBreaker = newCircuitBreaker("reserve", cfg.BreakerThreshold, cfg.BreakerCooldown)
+	startWebhook(cfg.WebhookURL, cfg.WebhookQueueSize, cfg.WebhookWorkers, cfg.WebhookTimeout)
+	notifier, _ = newNotifier(cfg.Notifier)
+	startDBStatsLogger(cfg.DBStatsInterval)
+	startSeatCounter(cfg.SeatCountReconcile)
+
+	// SIGINT/SIGTERM 수신 시 진행 중인 요청을 마치고 종료
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
 
+	srv := &http.Server{Addr: cfg.ListenAddr, Handler: recoverPanic(limitPerIP(cfg.PerIPInflight, newMux()))}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logJSON("FATAL", "main", 0, 0, "listen_fail", err)
+			log.Fatal(err)
+		}
+	}()
 	logJSON("INFO", "main", 0, 0, "server_start", nil)
-	log.Fatal(http.ListenAndServe(":8080", nil))
+
+	grpcSrv, err := startGRPCServer(cfg.GRPCAddr)
+	if err != nil {
+		logJSON("FATAL", "main", 0, 0, "grpc_listen_fail", err)
+		log.Fatal(err)
+	}
+	if grpcSrv != nil {
+		logJSON("INFO", "main", 0, 0, "grpc_server_start", nil)
+	}
+
+	<-ctx.Done()
+	logJSON("INFO", "main", 0, 0, "shutdown_start", nil)
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logJSON("WARN", "main", 0, 0, "shutdown_fail", err)
+	}
+	if grpcSrv != nil {
+		stopGRPCServer(shutdownCtx, grpcSrv)
+	}
+	logReserveSummary()
+	logJSON("INFO", "main", 0, 0, "server_stop", nil)
 }
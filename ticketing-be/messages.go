@@ -0,0 +1,92 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// 사용자에게 보여 주는 문구 번역 (MESSAGES_FILE)
+// 파일 형식은 언어 태그별로 영어 원문을 키로 한 번역 표
+//
+//	{"ko": {"Reservation successful": "예매가 완료되었습니다"}}
+//
+// 번역이 없으면 영어 원문을 그대로 쓴다
+var messageCatalog map[string]map[string]string
+
+func loadMessages(path string) error {
+	if path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	var catalog map[string]map[string]string
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return err
+	}
+	messageCatalog = make(map[string]map[string]string, len(catalog))
+	for lang, msgs := range catalog {
+		messageCatalog[strings.ToLower(lang)] = msgs
+	}
+	return nil
+}
+
+// Accept-Language 의 언어 태그를 q 값이 높은 순으로
+func acceptedLanguages(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var langs []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if f, err := strconv.ParseFloat(v, 64); err == nil {
+				q = f
+			}
+		}
+		if tag != "" && tag != "*" && q > 0 {
+			langs = append(langs, weighted{strings.ToLower(tag), q})
+		}
+	}
+	slices.SortStableFunc(langs, func(a, b weighted) int {
+		switch {
+		case a.q > b.q:
+			return -1
+		case a.q < b.q:
+			return 1
+		}
+		return 0
+	})
+
+	tags := make([]string, len(langs))
+	for i, l := range langs {
+		tags[i] = l.tag
+	}
+	return tags
+}
+
+// 요청의 Accept-Language 에 맞춰 문구 번역 (ko-KR 에 번역이 없으면 ko 도 찾아봄)
+func localize(r *http.Request, text string) string {
+	if messageCatalog == nil {
+		return text
+	}
+	for _, tag := range acceptedLanguages(r.Header.Get("Accept-Language")) {
+		primary, _, _ := strings.Cut(tag, "-")
+		for _, lang := range []string{tag, primary} {
+			if msg, ok := messageCatalog[lang][text]; ok {
+				return msg
+			}
+		}
+		if primary == "en" {
+			return text // 원문이 영어이므로 더 낮은 순위 언어를 찾지 않음
+		}
+	}
+	return text
+}
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"database/sql"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 기동 이후 예매 결과 누적 집계
+var reserveStats struct {
+	succeeded  atomic.Int64
+	replayed   atomic.Int64 // 같은 nonce 재요청에 돌려준 이전 성공 (새 예매가 아님)
+	conflicted atomic.Int64
+	declined   atomic.Int64 // 402: 결제 거절
+	aborted    atomic.Int64 // 499: 결제 도중 클라이언트가 연결을 끊음
+	errored    atomic.Int64
+}
+
+// 결제 포함 예매 (/reserve/pay) 의 결제 단계 결과
+// 결제 도중 연결이 끊겨 선점한 좌석을 풀어 준 건을 abandoned (장바구니 이탈) 로 센다
+var paymentStats struct {
+	confirmed atomic.Int64
+	declined  atomic.Int64
+	abandoned atomic.Int64
+}
+
+// 지금 처리를 기다리는 요청 수 (포화의 선행 지표)
+var queueDepth struct {
+	reserveInflight atomic.Int64 // 예매 핸들러 안에 있는 요청, 대부분 DB 커넥션이나 행 잠금을 기다린다
+	fifoWaiting     atomic.Int64 // SEAT_FIFO 큐에서 차례를 기다리는 요청
+}
+
+func formatQueueDepth() string {
+	return fmt.Sprintf("reserve_inflight=%d fifo_waiting=%d", queueDepth.reserveInflight.Load(), queueDepth.fifoWaiting.Load())
+}
+
+// 구역별 예매 성공 좌석 수 (어느 구역이 먼저 팔리는지 시계열로 보기 위함)
+var sectionReservations struct {
+	mu     sync.Mutex
+	counts map[string]int64
+}
+
+func countSectionReservation(seatID int) {
+	section := sectionFor(seatID)
+	sectionReservations.mu.Lock()
+	defer sectionReservations.mu.Unlock()
+	if sectionReservations.counts == nil {
+		sectionReservations.counts = make(map[string]int64)
+	}
+	sectionReservations.counts[section]++
+}
+
+// 응답 코드를 기록하는 ResponseWriter
+type statusRecorder struct {
+	http.ResponseWriter
+	status   int
+	replayed bool // markReplayed 로 표시한 nonce 재요청 응답
+}
+
+// 이번 응답이 nonce 재요청에 대한 이전 성공임을 집계에 알림 (200 이지만 새 예매로 세지 않음)
+func markReplayed(w http.ResponseWriter) {
+	if rec, ok := w.(*statusRecorder); ok {
+		rec.replayed = true
+	}
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.status = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// 예매 핸들러의 응답 코드로 성공/충돌/오류 집계
+func countReserveOutcome(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		queueDepth.reserveInflight.Add(1)
+		defer queueDepth.reserveInflight.Add(-1)
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next(rec, r)
+		switch {
+		case rec.replayed:
+			reserveStats.replayed.Add(1)
+		case rec.status == http.StatusOK || rec.status == http.StatusMultiStatus:
+			reserveStats.succeeded.Add(1)
+		case rec.status == http.StatusConflict || rec.status == http.StatusGone: // 410: MAX_TOTAL_RESERVATIONS 상한
+			reserveStats.conflicted.Add(1)
+		case rec.status == http.StatusPaymentRequired:
+			reserveStats.declined.Add(1)
+		case rec.status == statusClientClosedRequest:
+			reserveStats.aborted.Add(1)
+		case rec.status >= http.StatusInternalServerError:
+			reserveStats.errored.Add(1)
+		}
+	}
+}
+
+// 누적 집계를 한 줄 로그로 출력 (종료 시 호출)
+func logReserveSummary() {
+	logJSON("INFO", "summary", 0, 0, fmt.Sprintf("succeeded=%d replayed=%d conflicted=%d declined=%d aborted=%d errored=%d",
+		reserveStats.succeeded.Load(), reserveStats.replayed.Load(), reserveStats.conflicted.Load(),
+		reserveStats.declined.Load(), reserveStats.aborted.Load(), reserveStats.errored.Load()), nil)
+	logJSON("INFO", "payment_summary", 0, 0, fmt.Sprintf("confirmed=%d declined=%d abandoned=%d",
+		paymentStats.confirmed.Load(), paymentStats.declined.Load(), paymentStats.abandoned.Load()), nil)
+}
+
+// 커넥션 풀 상태를 한 줄 요약으로
+func formatDBStats(st sql.DBStats) string {
+	return fmt.Sprintf("open=%d in_use=%d idle=%d wait_count=%d wait_duration=%s",
+		st.OpenConnections, st.InUse, st.Idle, st.WaitCount, st.WaitDuration)
+}
+
+// 주기적으로 커넥션 풀 상태와 대기 요청 수 로그 출력 (interval 이 0 이면 비활성)
+func startDBStatsLogger(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	go func() {
+		for range time.Tick(interval) {
+			logJSON("INFO", "db_stats", 0, 0, formatDBStats(db.Stats()), nil)
+			logJSON("INFO", "queue_depth", 0, 0, formatQueueDepth(), nil)
+		}
+	}()
+}
+
+// Prometheus 텍스트 형식 메트릭 반환
+func metricsHandler(w http.ResponseWriter, r *http.Request) {
+	var b strings.Builder
+	writeMetric := func(name, typ, help string, value any) {
+		fmt.Fprintf(&b, "# HELP %s %s\n# TYPE %s %s\n%s %v\n", name, help, name, typ, name, value)
+	}
+
+	st := db.Stats()
+	writeMetric("ticketing_db_max_open_connections", "gauge", "Maximum number of open connections to the database.", st.MaxOpenConnections)
+	writeMetric("ticketing_db_open_connections", "gauge", "Number of established connections, both in use and idle.", st.OpenConnections)
+	writeMetric("ticketing_db_in_use_connections", "gauge", "Number of connections currently in use.", st.InUse)
+	writeMetric("ticketing_db_idle_connections", "gauge", "Number of idle connections.", st.Idle)
+	writeMetric("ticketing_db_wait_count_total", "counter", "Total number of connections waited for.", st.WaitCount)
+	writeMetric("ticketing_db_wait_duration_seconds_total", "counter", "Total time blocked waiting for a new connection.", st.WaitDuration.Seconds())
+	writeMetric("ticketing_reserve_inflight", "gauge", "Reserve requests currently being handled, mostly waiting on DB connections or row locks.", queueDepth.reserveInflight.Load())
+	writeMetric("ticketing_seat_fifo_waiting", "gauge", "Reserve requests waiting for their turn in a SEAT_FIFO seat queue.", queueDepth.fifoWaiting.Load())
+
+	fmt.Fprintf(&b, "# HELP ticketing_reservations_total Reservation requests by outcome since startup.\n# TYPE ticketing_reservations_total counter\n")
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"succeeded\"} %d\n", reserveStats.succeeded.Load())
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"replayed\"} %d\n", reserveStats.replayed.Load())
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"conflicted\"} %d\n", reserveStats.conflicted.Load())
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"declined\"} %d\n", reserveStats.declined.Load())
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"aborted\"} %d\n", reserveStats.aborted.Load())
+	fmt.Fprintf(&b, "ticketing_reservations_total{outcome=\"errored\"} %d\n", reserveStats.errored.Load())
+
+	fmt.Fprintf(&b, "# HELP ticketing_payments_total Payment step outcomes of /reserve/pay since startup.\n# TYPE ticketing_payments_total counter\n")
+	fmt.Fprintf(&b, "ticketing_payments_total{outcome=\"confirmed\"} %d\n", paymentStats.confirmed.Load())
+	fmt.Fprintf(&b, "ticketing_payments_total{outcome=\"declined\"} %d\n", paymentStats.declined.Load())
+	fmt.Fprintf(&b, "ticketing_payments_total{outcome=\"abandoned\"} %d\n", paymentStats.abandoned.Load())
+
+	fmt.Fprintf(&b, "# HELP ticketing_section_reservations_total Seats reserved by section since startup.\n# TYPE ticketing_section_reservations_total counter\n")
+	sectionReservations.mu.Lock()
+	sections := make([]string, 0, len(sectionReservations.counts))
+	for name := range sectionReservations.counts {
+		sections = append(sections, name)
+	}
+	slices.Sort(sections)
+	for _, name := range sections {
+		fmt.Fprintf(&b, "ticketing_section_reservations_total{section=%q} %d\n", name, sectionReservations.counts[name])
+	}
+	sectionReservations.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprint(w, b.String())
+}
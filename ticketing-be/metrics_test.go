@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+)
+
+// 응답 코드별로 맞는 집계에 들어가는지, nonce 재요청 200 은 새 성공으로 세지 않는지
+func TestCountReserveOutcome(t *testing.T) {
+	cases := []struct {
+		name    string
+		handler http.HandlerFunc
+		counter *atomic.Int64
+	}{
+		{"success", func(w http.ResponseWriter, r *http.Request) {}, &reserveStats.succeeded},
+		{"replayed", func(w http.ResponseWriter, r *http.Request) { markReplayed(w) }, &reserveStats.replayed},
+		{"conflict", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusConflict) }, &reserveStats.conflicted},
+		{"sold out", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusGone) }, &reserveStats.conflicted},
+		{"payment declined", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusPaymentRequired) }, &reserveStats.declined},
+		{"payment aborted", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(statusClientClosedRequest) }, &reserveStats.aborted},
+		{"error", func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusInternalServerError) }, &reserveStats.errored},
+	}
+	counters := []*atomic.Int64{&reserveStats.succeeded, &reserveStats.replayed, &reserveStats.conflicted,
+		&reserveStats.declined, &reserveStats.aborted, &reserveStats.errored}
+
+	for _, c := range cases {
+		before := make([]int64, len(counters))
+		for i, ctr := range counters {
+			before[i] = ctr.Load()
+		}
+		countReserveOutcome(c.handler)(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/reserve", nil))
+		for i, ctr := range counters {
+			want := before[i]
+			if ctr == c.counter {
+				want++
+			}
+			if got := ctr.Load(); got != want {
+				t.Fatalf("%s: counter %d = %d, want %d", c.name, i, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"runtime/debug"
+	"sync"
+)
+
+// 핸들러 panic 복구: 해당 요청만 500 으로 끝내고 서버는 계속 동작
+func recoverPanic(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+			if rec == http.ErrAbortHandler {
+				panic(rec) // net/http 가 의도적으로 연결을 끊는 경우
+			}
+			logJSON("ERROR", "panic", 0, 0, r.Method+" "+r.URL.Path, fmt.Errorf("%v\n%s", rec, debug.Stack()))
+			http.Error(w, "internal server error", http.StatusInternalServerError)
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+// 클라이언트 IP 별 동시 처리 중인 요청 수 제한 (PER_IP_MAX_INFLIGHT, 0 이면 제한 없음)
+// 한 장비가 연결을 수천 개 여는 부하 테스트처럼 단일 출처 폭주를 429 로 막는다
+// 프록시 뒤라면 모든 요청이 프록시 IP 로 잡히므로 X-Forwarded-For 는 믿지 않고 RemoteAddr 만 쓴다
+func limitPerIP(limit int, next http.Handler) http.Handler {
+	if limit <= 0 {
+		return next
+	}
+	var (
+		mu       sync.Mutex
+		inflight = make(map[string]int)
+	)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ip, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			ip = r.RemoteAddr
+		}
+
+		mu.Lock()
+		if inflight[ip] >= limit {
+			mu.Unlock()
+			http.Error(w, "Too many concurrent requests", http.StatusTooManyRequests)
+			logJSON("WARN", "ip_limit", 0, 0, "too_many_inflight", fmt.Errorf("ip=%s", ip))
+			return
+		}
+		inflight[ip]++
+		mu.Unlock()
+
+		defer func() {
+			mu.Lock()
+			if inflight[ip]--; inflight[ip] == 0 {
+				delete(inflight, ip) // 끝난 IP 는 지워 맵이 커지지 않게
+			}
+			mu.Unlock()
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
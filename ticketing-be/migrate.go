@@ -0,0 +1,66 @@
+package main
+
+import "database/sql"
+
+// seats 테이블에 나중에 추가된 컬럼 (CREATE TABLE 의 정의와 같게 유지)
+// CREATE TABLE IF NOT EXISTS 는 이전 버전이 만든 테이블을 바꾸지 않으므로 없는 컬럼만 ALTER TABLE 로 붙인다
+var seatColumnMigrations = []struct {
+	column string
+	ddl    string
+	layout bool // 좌석 배치/가격에서 정해지는 값이라 추가 후 기존 행을 채워야 함
+}{
+	{"reserved_at", "ADD COLUMN reserved_at DATETIME", false},
+	{"seat_row", "ADD COLUMN seat_row INT", true},
+	{"seat_col", "ADD COLUMN seat_col INT", true},
+	{"label", "ADD COLUMN label VARCHAR(16)", true},
+	{"section", "ADD COLUMN section VARCHAR(32) NOT NULL DEFAULT 'general'", true},
+	{"nonce", "ADD COLUMN nonce VARCHAR(64) UNIQUE", false},
+	{"reservation_seq", "ADD COLUMN reservation_seq BIGINT", false},
+	{"price", "ADD COLUMN price INT NOT NULL DEFAULT 0", true},
+	{"operator_id", "ADD COLUMN operator_id INT", false},
+	{"confirmation_code", "ADD COLUMN confirmation_code CHAR(9) UNIQUE", false},
+}
+
+// 기존 seats 테이블에 없는 컬럼과 idx_user_status 인덱스 추가 (여러 번 실행해도 같은 결과)
+// 배치 컬럼을 새로 붙였으면 true 를 돌려주어 initSeats 가 기존 행의 배치 값을 다시 쓰게 한다
+func migrateSeatsTable() (bool, error) {
+	backfill := false
+	for _, m := range seatColumnMigrations {
+		exists, err := schemaHas(`SELECT COUNT(*) FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'seats' AND COLUMN_NAME = ?`, m.column)
+		if err != nil {
+			logJSON("ERROR", "init_seats", 0, 0, "migrate_lookup_fail", err)
+			return false, err
+		}
+		if exists {
+			continue
+		}
+		if _, err := db.Exec(`ALTER TABLE seats ` + m.ddl); err != nil {
+			logJSON("ERROR", "init_seats", 0, 0, "migrate_"+m.column+"_fail", err)
+			return false, err
+		}
+		logJSON("INFO", "init_seats", 0, 0, "migrated_"+m.column, nil)
+		backfill = backfill || m.layout
+	}
+
+	exists, err := schemaHas(`SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'seats' AND INDEX_NAME = ?`, "idx_user_status")
+	if err != nil {
+		logJSON("ERROR", "init_seats", 0, 0, "migrate_lookup_fail", err)
+		return false, err
+	}
+	if !exists {
+		if _, err := db.Exec(`ALTER TABLE seats ADD INDEX idx_user_status (user_id, status)`); err != nil {
+			logJSON("ERROR", "init_seats", 0, 0, "migrate_idx_user_status_fail", err)
+			return false, err
+		}
+		logJSON("INFO", "init_seats", 0, 0, "migrated_idx_user_status", nil)
+	}
+	return backfill, nil
+}
+
+func schemaHas(query, name string) (bool, error) {
+	var n int
+	if err := db.QueryRow(query, name).Scan(&n); err != nil && err != sql.ErrNoRows {
+		return false, err
+	}
+	return n > 0, nil
+}
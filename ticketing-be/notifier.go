@@ -0,0 +1,34 @@
+package main
+
+import "fmt"
+
+// 예매 확정 알림 (이메일 등 실제 발송 구현을 끼워 넣는 지점)
+type Notifier interface {
+	NotifyReservation(userID, seatID int)
+}
+
+// 아무것도 하지 않는 기본 구현
+type noopNotifier struct{}
+
+func (noopNotifier) NotifyReservation(userID, seatID int) {}
+
+// 발송 대신 로그만 남기는 구현
+type logNotifier struct{}
+
+func (logNotifier) NotifyReservation(userID, seatID int) {
+	logJSON("INFO", "notify", userID, seatID, "confirmation_sent", nil)
+}
+
+var notifier Notifier = noopNotifier{}
+
+// NOTIFIER 설정 값으로 구현 선택
+func newNotifier(kind string) (Notifier, error) {
+	switch kind {
+	case "", "none":
+		return noopNotifier{}, nil
+	case "log":
+		return logNotifier{}, nil
+	default:
+		return nil, fmt.Errorf("unknown notifier %q", kind)
+	}
+}
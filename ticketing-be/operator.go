@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+type OperatorRequest struct {
+	OperatorID int    `json:"operator_id"` // 예매를 처리한 직원
+	UserID     int    `json:"user_id"`     // 좌석을 받는 사용자
+	SeatID     int    `json:"seat_id"`
+	Nonce      string `json:"nonce,omitempty"`
+}
+
+// 현장 판매 등 직원이 사용자 대신 예매 (operator_id 컬럼에 처리한 직원을 남김)
+// 다른 사용자 명의로 예매하므로 관리자 토큰이 필요하고, 사용자 JWT 검사는 하지 않는다
+func reserveOperatorHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_operator", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req OperatorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_operator", 0, 0, "invalid_json", err)
+		return
+	}
+	if req.OperatorID <= 0 {
+		http.Error(w, "operator_id is required", http.StatusBadRequest)
+		logJSON("WARN", "reserve_operator", req.UserID, req.SeatID, "bad_operator", nil)
+		return
+	}
+	if len(req.Nonce) > maxNonceLength {
+		http.Error(w, fmt.Sprintf("nonce must be at most %d characters", maxNonceLength), http.StatusBadRequest)
+		logJSON("WARN", "reserve_operator", req.UserID, req.SeatID, "bad_nonce", nil)
+		return
+	}
+
+	logJSON("INFO", "reserve_operator", req.UserID, req.SeatID, fmt.Sprintf("operator=%d", req.OperatorID), nil)
+	reserveAndRespond(w, r, "reserve_operator", TicketRequest{UserID: req.UserID, SeatID: req.SeatID, Nonce: req.Nonce}, req.OperatorID)
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type PayRequest struct {
+	UserID       int    `json:"user_id"`
+	SeatID       int    `json:"seat_id"`
+	PaymentToken string `json:"payment_token"` // 모의 결제 토큰, "tok_decline" 이면 항상 거절
+}
+
+var errPaymentDeclined = errors.New("payment declined")
+
+// 모의 결제 대행사 호출 (PAYMENT_LATENCY 만큼 걸리고 PAYMENT_FAIL_RATE 확률로 거절)
+func chargePayment(ctx context.Context, token string) error {
+	t := time.NewTimer(cfg.PaymentLatency)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+	}
+	if token == "tok_decline" || rand.Float64() < cfg.PaymentFailRate {
+		return errPaymentDeclined
+	}
+	return nil
+}
+
+// 결제 도중 클라이언트가 연결을 끊은 요청 (nginx 관례)
+const statusClientClosedRequest = 499
+
+// 결제까지 포함한 예매
+// 결제 전에 짧은 트랜잭션으로 좌석을 예매 상태로 잡아 (선점) 상한까지 확정하고 커밋한 뒤 결제한다
+// 결제 대기 동안 좌석 행도 상한 행도 잠그지 않으므로 같은 좌석의 다른 요청은 기다리지 않고 바로 409 를 받는다
+// 결제가 거절되거나 중단되면 선점을 풀어 빈 좌석으로 되돌린다 (결제 중 서버가 죽으면 선점이 남으므로 관리자 초기화로 푼다)
+func reservePayHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_pay", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req PayRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_pay", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "reserve_pay", req.UserID, req.SeatID) {
+		return
+	}
+	if req.PaymentToken == "" {
+		http.Error(w, "payment_token is required", http.StatusBadRequest)
+		logJSON("WARN", "reserve_pay", req.UserID, req.SeatID, "missing_payment_token", nil)
+		return
+	}
+
+	// 선점: 좌석 → 상한 순으로 잠그고 예매 상태로 커밋
+	held, ok := holdSeatForPayment(w, r, req)
+	if !ok {
+		return
+	}
+	invalidateSeatCache() // 결제 중에도 빈 좌석 목록에서 빠지도록
+
+	start := time.Now()
+	if err := chargePayment(r.Context(), req.PaymentToken); errors.Is(err, errPaymentDeclined) {
+		paymentStats.declined.Add(1)
+		releasePaymentHold(req.UserID, held)
+		http.Error(w, localize(r, "Payment declined"), http.StatusPaymentRequired)
+		logJSON("INFO", "reserve_pay", req.UserID, req.SeatID, "payment_declined", nil)
+		return
+	} else if err != nil {
+		// 클라이언트가 결제 중에 연결을 끊음
+		// 응답을 쓰지 않으면 statusRecorder 가 200 으로 남아 성공으로 집계되므로 명시적으로 기록
+		paymentStats.abandoned.Add(1)
+		releasePaymentHold(req.UserID, held)
+		w.WriteHeader(statusClientClosedRequest)
+		logJSON("WARN", "reserve_pay", req.UserID, req.SeatID, "payment_aborted", err)
+		return
+	}
+	paid := time.Since(start)
+
+	paymentStats.confirmed.Add(1)
+	logJSON("INFO", "reserve_pay", req.UserID, req.SeatID, "success", nil)
+	afterReserve(req.UserID, []int{req.SeatID}, held.ReservedAt)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "reserve_pay", req.UserID, req.SeatID, map[string]any{
+		"message":           localize(r, "Reservation successful"),
+		"reservation_seq":   held.Seq,
+		"confirmation_code": formatConfirmationCode(held.Code),
+		"reserved_at":       held.ReservedAt,
+		"payment_ms":        paid.Milliseconds(),
+	})
+}
+
+// 결제 전 선점 트랜잭션, 실패하면 응답을 쓰고 ok=false
+func holdSeatForPayment(w http.ResponseWriter, r *http.Request, req PayRequest) (reservedSeat, bool) {
+	tx, err := beginReserveTx()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_pay", req.UserID, req.SeatID, "tx_begin_fail", err)
+		return reservedSeat{}, false
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_pay", req.UserID, req.SeatID, "lock_timeout_set_fail", err)
+		return reservedSeat{}, false
+	}
+
+	var status SeatStatus
+	err = tx.QueryRow(`SELECT status FROM seats WHERE seat_id = ? FOR UPDATE`, req.SeatID).Scan(&status)
+	if err == sql.ErrNoRows {
+		http.Error(w, localize(r, "Seat not found"), http.StatusNotFound)
+		logJSON("WARN", "reserve_pay", req.UserID, req.SeatID, "seat_not_found", nil)
+		return reservedSeat{}, false
+	} else if isLockWaitTimeout(err) {
+		setRetryAfter(w)
+		http.Error(w, localize(r, "Seat is locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "reserve_pay", req.UserID, req.SeatID, "lock_wait_timeout", nil)
+		return reservedSeat{}, false
+	} else if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_pay", req.UserID, req.SeatID, "select_fail", err)
+		return reservedSeat{}, false
+	}
+	if status == SeatDisabled {
+		http.Error(w, localize(r, "Seat is not for sale"), http.StatusConflict)
+		logJSON("INFO", "reserve_pay", req.UserID, req.SeatID, "seat_disabled", nil)
+		return reservedSeat{}, false
+	} else if status != SeatAvailable {
+		setRetryAfter(w)
+		http.Error(w, localize(r, "Seat already reserved"), http.StatusConflict)
+		logJSON("INFO", "reserve_pay", req.UserID, req.SeatID, "seat_conflict", nil)
+		return reservedSeat{}, false
+	}
+
+	if _, ok := checkReservationCap(w, tx, "reserve_pay", req.UserID, req.SeatID, 1); !ok {
+		return reservedSeat{}, false
+	}
+
+	reserved, err := reserveSeatsTx(tx, req.UserID, []int{req.SeatID})
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_pay", req.UserID, req.SeatID, stage, cause)
+		return reservedSeat{}, false
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_pay", req.UserID, req.SeatID, "commit_fail", err)
+		return reservedSeat{}, false
+	}
+	return reserved[0], true
+}
+
+// 결제에 실패한 선점을 풀어 빈 좌석으로 되돌림 (그 사이 관리자가 바꾼 좌석은 확인 코드가 달라 건드리지 않음)
+func releasePaymentHold(userID int, held reservedSeat) {
+	_, err := db.Exec(`UPDATE seats SET status = ?, user_id = NULL, reserved_at = NULL, nonce = NULL, reservation_seq = NULL, operator_id = NULL, confirmation_code = NULL WHERE seat_id = ? AND confirmation_code = ?`, SeatAvailable, held.SeatID, held.Code)
+	if err != nil {
+		logJSON("ERROR", "reserve_pay", userID, held.SeatID, "hold_release_fail", err)
+		return
+	}
+	invalidateSeatCache()
+}
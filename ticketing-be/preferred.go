@@ -0,0 +1,133 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+type PreferredRequest struct {
+	UserID  int   `json:"user_id"`
+	SeatIDs []int `json:"seat_ids"` // 선호 순서
+}
+
+const maxPreferredCount = 20
+
+// 선호 순서대로 좌석을 살펴 처음 비어 있는 좌석 하나만 예매
+// 후보 행을 seat_id 순으로 한꺼번에 잠근 뒤 고르므로 한 트랜잭션 안에서 끝난다
+func reservePreferredHandler(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		http.Error(w, "Content-Type must be application/json", http.StatusUnsupportedMediaType)
+		logJSON("WARN", "reserve_preferred", 0, 0, "bad_content_type", nil)
+		return
+	}
+
+	var req PreferredRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		logJSON("ERROR", "reserve_preferred", 0, 0, "invalid_json", err)
+		return
+	}
+	if !authorizeUser(w, r, "reserve_preferred", req.UserID, 0) {
+		return
+	}
+	// 중복은 처음 나온 순위만 남긴다
+	prefs := make([]int, 0, len(req.SeatIDs))
+	for _, id := range req.SeatIDs {
+		if !slices.Contains(prefs, id) {
+			prefs = append(prefs, id)
+		}
+	}
+	if len(prefs) == 0 || len(prefs) > maxPreferredCount {
+		http.Error(w, fmt.Sprintf("seat_ids must contain between 1 and %d seats", maxPreferredCount), http.StatusBadRequest)
+		logJSON("WARN", "reserve_preferred", req.UserID, 0, "bad_count", nil)
+		return
+	}
+
+	tx, err := beginReserveTx()
+	if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_preferred", req.UserID, 0, "tx_begin_fail", err)
+		return
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_preferred", req.UserID, 0, "lock_timeout_set_fail", err)
+		return
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(prefs)), ",")
+	args := make([]any, len(prefs))
+	for i, id := range prefs {
+		args[i] = id
+	}
+
+	// 잠금 순서를 고정해 교착 상태 방지
+	rows, err := tx.Query(`SELECT seat_id, status FROM seats WHERE seat_id IN (`+placeholders+`) ORDER BY seat_id FOR UPDATE`, args...)
+	if isLockWaitTimeout(err) {
+		setRetryAfter(w)
+		http.Error(w, localize(r, "Seats are locked by another reservation"), http.StatusConflict)
+		logJSON("INFO", "reserve_preferred", req.UserID, 0, "lock_wait_timeout", err)
+		return
+	} else if err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_preferred", req.UserID, 0, "select_fail", err)
+		return
+	}
+	statuses := make(map[int]SeatStatus)
+	for rows.Next() {
+		var id int
+		var status SeatStatus
+		if err := rows.Scan(&id, &status); err == nil {
+			statuses[id] = status
+		}
+	}
+	rows.Close()
+
+	seatID := 0
+	for _, id := range prefs {
+		if statuses[id] == SeatAvailable {
+			seatID = id
+			break
+		}
+	}
+	if seatID == 0 {
+		setRetryAfter(w)
+		http.Error(w, localize(r, "None of the preferred seats are available"), http.StatusConflict)
+		logJSON("INFO", "reserve_preferred", req.UserID, 0, "seat_conflict", nil)
+		return
+	}
+
+	if _, ok := checkReservationCap(w, tx, "reserve_preferred", req.UserID, seatID, 1); !ok {
+		return
+	}
+
+	reserved, err := reserveSeatsTx(tx, req.UserID, []int{seatID})
+	if err != nil {
+		stage, cause := splitReserveError(err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_preferred", req.UserID, seatID, stage, cause)
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		logJSON("ERROR", "reserve_preferred", req.UserID, seatID, "commit_fail", err)
+		return
+	}
+
+	logJSON("INFO", "reserve_preferred", req.UserID, seatID, "success", nil)
+	afterReserve(req.UserID, []int{seatID}, reserved[0].ReservedAt)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "reserve_preferred", req.UserID, seatID, map[string]any{
+		"message":           localize(r, "Reservation successful"),
+		"seat_id":           seatID,
+		"reservation_seq":   reserved[0].Seq,
+		"confirmation_code": formatConfirmationCode(reserved[0].Code),
+		"reserved_at":       reserved[0].ReservedAt,
+	})
+}
@@ -0,0 +1,4 @@
+// ticketing.proto 로 만든 gRPC 스텁 (protoc, protoc-gen-go, protoc-gen-go-grpc 필요)
+package ticketingpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative ticketing.proto
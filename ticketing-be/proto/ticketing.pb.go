@@ -0,0 +1,394 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        v5.27.3
+// source: ticketing.proto
+
+package ticketingpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// JWT_SECRET 이 있으면 메타데이터 authorization 에 "Bearer <token>" 이 필요하다
+type SeatRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	UserId int32  `protobuf:"varint,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	SeatId int32  `protobuf:"varint,2,opt,name=seat_id,json=seatId,proto3" json:"seat_id,omitempty"`
+	Nonce  string `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"` // Reserve 전용, HTTP 의 nonce 와 같은 재전송 식별자
+}
+
+func (x *SeatRequest) Reset() {
+	*x = SeatRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ticketing_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SeatRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SeatRequest) ProtoMessage() {}
+
+func (x *SeatRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticketing_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SeatRequest.ProtoReflect.Descriptor instead.
+func (*SeatRequest) Descriptor() ([]byte, []int) {
+	return file_ticketing_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *SeatRequest) GetUserId() int32 {
+	if x != nil {
+		return x.UserId
+	}
+	return 0
+}
+
+func (x *SeatRequest) GetSeatId() int32 {
+	if x != nil {
+		return x.SeatId
+	}
+	return 0
+}
+
+func (x *SeatRequest) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+// status 는 HTTP 응답의 로그 status 와 같은 값 (success, replayed, seat_conflict, seat_not_found, ...)
+// 나머지는 Reserve 성공 (success, replayed) 때만 채운다
+type ReserveReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Status           string `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	ReservationSeq   int64  `protobuf:"varint,2,opt,name=reservation_seq,json=reservationSeq,proto3" json:"reservation_seq,omitempty"`
+	ConfirmationCode string `protobuf:"bytes,3,opt,name=confirmation_code,json=confirmationCode,proto3" json:"confirmation_code,omitempty"`
+	ReservedAt       string `protobuf:"bytes,4,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"` // RFC 3339 (UTC)
+}
+
+func (x *ReserveReply) Reset() {
+	*x = ReserveReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ticketing_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ReserveReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveReply) ProtoMessage() {}
+
+func (x *ReserveReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ticketing_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveReply.ProtoReflect.Descriptor instead.
+func (*ReserveReply) Descriptor() ([]byte, []int) {
+	return file_ticketing_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReserveReply) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ReserveReply) GetReservationSeq() int64 {
+	if x != nil {
+		return x.ReservationSeq
+	}
+	return 0
+}
+
+func (x *ReserveReply) GetConfirmationCode() string {
+	if x != nil {
+		return x.ConfirmationCode
+	}
+	return ""
+}
+
+func (x *ReserveReply) GetReservedAt() string {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return ""
+}
+
+type ListAvailableRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ListAvailableRequest) Reset() {
+	*x = ListAvailableRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ticketing_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAvailableRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAvailableRequest) ProtoMessage() {}
+
+func (x *ListAvailableRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ticketing_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAvailableRequest.ProtoReflect.Descriptor instead.
+func (*ListAvailableRequest) Descriptor() ([]byte, []int) {
+	return file_ticketing_proto_rawDescGZIP(), []int{2}
+}
+
+type ListAvailableReply struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	SeatIds []int32 `protobuf:"varint,1,rep,packed,name=seat_ids,json=seatIds,proto3" json:"seat_ids,omitempty"`
+}
+
+func (x *ListAvailableReply) Reset() {
+	*x = ListAvailableReply{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_ticketing_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ListAvailableReply) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListAvailableReply) ProtoMessage() {}
+
+func (x *ListAvailableReply) ProtoReflect() protoreflect.Message {
+	mi := &file_ticketing_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListAvailableReply.ProtoReflect.Descriptor instead.
+func (*ListAvailableReply) Descriptor() ([]byte, []int) {
+	return file_ticketing_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ListAvailableReply) GetSeatIds() []int32 {
+	if x != nil {
+		return x.SeatIds
+	}
+	return nil
+}
+
+var File_ticketing_proto protoreflect.FileDescriptor
+
+var file_ticketing_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x22, 0x55, 0x0a, 0x0b,
+	0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x73, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x06, 0x73, 0x65, 0x61, 0x74, 0x49, 0x64, 0x12, 0x14, 0x0a,
+	0x05, 0x6e, 0x6f, 0x6e, 0x63, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x6e, 0x6f,
+	0x6e, 0x63, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x0c, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52,
+	0x65, 0x70, 0x6c, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x27, 0x0a, 0x0f,
+	0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x73, 0x65, 0x71, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0e, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x53, 0x65, 0x71, 0x12, 0x2b, 0x0a, 0x11, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x10, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x64, 0x5f, 0x61,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65,
+	0x64, 0x41, 0x74, 0x22, 0x16, 0x0a, 0x14, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c,
+	0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x2f, 0x0a, 0x12, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x19, 0x0a, 0x08, 0x73, 0x65, 0x61, 0x74, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x05, 0x52, 0x07, 0x73, 0x65, 0x61, 0x74, 0x49, 0x64, 0x73, 0x32, 0xd3, 0x01, 0x0a,
+	0x09, 0x54, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x3a, 0x0a, 0x07, 0x52, 0x65,
+	0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x16, 0x2e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e,
+	0x67, 0x2e, 0x53, 0x65, 0x61, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e,
+	0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76,
+	0x65, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x39, 0x0a, 0x06, 0x43, 0x61, 0x6e, 0x63, 0x65, 0x6c,
+	0x12, 0x16, 0x2e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x53, 0x65, 0x61,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x74, 0x69, 0x63, 0x6b, 0x65,
+	0x74, 0x69, 0x6e, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x4f, 0x0a, 0x0d, 0x4c, 0x69, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x12, 0x1f, 0x2e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2e, 0x4c,
+	0x69, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x41, 0x76, 0x61, 0x69, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x52, 0x65, 0x70,
+	0x6c, 0x79, 0x42, 0x20, 0x5a, 0x1e, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69, 0x6e, 0x67, 0x2d,
+	0x62, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x3b, 0x74, 0x69, 0x63, 0x6b, 0x65, 0x74, 0x69,
+	0x6e, 0x67, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_ticketing_proto_rawDescOnce sync.Once
+	file_ticketing_proto_rawDescData = file_ticketing_proto_rawDesc
+)
+
+func file_ticketing_proto_rawDescGZIP() []byte {
+	file_ticketing_proto_rawDescOnce.Do(func() {
+		file_ticketing_proto_rawDescData = protoimpl.X.CompressGZIP(file_ticketing_proto_rawDescData)
+	})
+	return file_ticketing_proto_rawDescData
+}
+
+var file_ticketing_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ticketing_proto_goTypes = []any{
+	(*SeatRequest)(nil),          // 0: ticketing.SeatRequest
+	(*ReserveReply)(nil),         // 1: ticketing.ReserveReply
+	(*ListAvailableRequest)(nil), // 2: ticketing.ListAvailableRequest
+	(*ListAvailableReply)(nil),   // 3: ticketing.ListAvailableReply
+}
+var file_ticketing_proto_depIdxs = []int32{
+	0, // 0: ticketing.Ticketing.Reserve:input_type -> ticketing.SeatRequest
+	0, // 1: ticketing.Ticketing.Cancel:input_type -> ticketing.SeatRequest
+	2, // 2: ticketing.Ticketing.ListAvailable:input_type -> ticketing.ListAvailableRequest
+	1, // 3: ticketing.Ticketing.Reserve:output_type -> ticketing.ReserveReply
+	1, // 4: ticketing.Ticketing.Cancel:output_type -> ticketing.ReserveReply
+	3, // 5: ticketing.Ticketing.ListAvailable:output_type -> ticketing.ListAvailableReply
+	3, // [3:6] is the sub-list for method output_type
+	0, // [0:3] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_ticketing_proto_init() }
+func file_ticketing_proto_init() {
+	if File_ticketing_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_ticketing_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*SeatRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ticketing_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*ReserveReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ticketing_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAvailableRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_ticketing_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*ListAvailableReply); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_ticketing_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ticketing_proto_goTypes,
+		DependencyIndexes: file_ticketing_proto_depIdxs,
+		MessageInfos:      file_ticketing_proto_msgTypes,
+	}.Build()
+	File_ticketing_proto = out.File
+	file_ticketing_proto_rawDesc = nil
+	file_ticketing_proto_goTypes = nil
+	file_ticketing_proto_depIdxs = nil
+}
@@ -0,0 +1,190 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             v5.27.3
+// source: ticketing.proto
+
+package ticketingpb
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	Ticketing_Reserve_FullMethodName       = "/ticketing.Ticketing/Reserve"
+	Ticketing_Cancel_FullMethodName        = "/ticketing.Ticketing/Cancel"
+	Ticketing_ListAvailable_FullMethodName = "/ticketing.Ticketing/ListAvailable"
+)
+
+// TicketingClient is the client API for Ticketing service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// HTTP 핸들러와 같은 예매 로직 (reserveSeat, cancelSeat, listAvailableSeats) 을 노출하는 gRPC 서비스
+type TicketingClient interface {
+	Reserve(ctx context.Context, in *SeatRequest, opts ...grpc.CallOption) (*ReserveReply, error)
+	Cancel(ctx context.Context, in *SeatRequest, opts ...grpc.CallOption) (*ReserveReply, error)
+	ListAvailable(ctx context.Context, in *ListAvailableRequest, opts ...grpc.CallOption) (*ListAvailableReply, error)
+}
+
+type ticketingClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTicketingClient(cc grpc.ClientConnInterface) TicketingClient {
+	return &ticketingClient{cc}
+}
+
+func (c *ticketingClient) Reserve(ctx context.Context, in *SeatRequest, opts ...grpc.CallOption) (*ReserveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveReply)
+	err := c.cc.Invoke(ctx, Ticketing_Reserve_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketingClient) Cancel(ctx context.Context, in *SeatRequest, opts ...grpc.CallOption) (*ReserveReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveReply)
+	err := c.cc.Invoke(ctx, Ticketing_Cancel_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *ticketingClient) ListAvailable(ctx context.Context, in *ListAvailableRequest, opts ...grpc.CallOption) (*ListAvailableReply, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListAvailableReply)
+	err := c.cc.Invoke(ctx, Ticketing_ListAvailable_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TicketingServer is the server API for Ticketing service.
+// All implementations must embed UnimplementedTicketingServer
+// for forward compatibility
+//
+// HTTP 핸들러와 같은 예매 로직 (reserveSeat, cancelSeat, listAvailableSeats) 을 노출하는 gRPC 서비스
+type TicketingServer interface {
+	Reserve(context.Context, *SeatRequest) (*ReserveReply, error)
+	Cancel(context.Context, *SeatRequest) (*ReserveReply, error)
+	ListAvailable(context.Context, *ListAvailableRequest) (*ListAvailableReply, error)
+	mustEmbedUnimplementedTicketingServer()
+}
+
+// UnimplementedTicketingServer must be embedded to have forward compatible implementations.
+type UnimplementedTicketingServer struct {
+}
+
+func (UnimplementedTicketingServer) Reserve(context.Context, *SeatRequest) (*ReserveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Reserve not implemented")
+}
+func (UnimplementedTicketingServer) Cancel(context.Context, *SeatRequest) (*ReserveReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedTicketingServer) ListAvailable(context.Context, *ListAvailableRequest) (*ListAvailableReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAvailable not implemented")
+}
+func (UnimplementedTicketingServer) mustEmbedUnimplementedTicketingServer() {}
+
+// UnsafeTicketingServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TicketingServer will
+// result in compilation errors.
+type UnsafeTicketingServer interface {
+	mustEmbedUnimplementedTicketingServer()
+}
+
+func RegisterTicketingServer(s grpc.ServiceRegistrar, srv TicketingServer) {
+	s.RegisterService(&Ticketing_ServiceDesc, srv)
+}
+
+func _Ticketing_Reserve_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketingServer).Reserve(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ticketing_Reserve_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketingServer).Reserve(ctx, req.(*SeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ticketing_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SeatRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketingServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ticketing_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketingServer).Cancel(ctx, req.(*SeatRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Ticketing_ListAvailable_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAvailableRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TicketingServer).ListAvailable(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Ticketing_ListAvailable_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TicketingServer).ListAvailable(ctx, req.(*ListAvailableRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Ticketing_ServiceDesc is the grpc.ServiceDesc for Ticketing service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Ticketing_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "ticketing.Ticketing",
+	HandlerType: (*TicketingServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Reserve",
+			Handler:    _Ticketing_Reserve_Handler,
+		},
+		{
+			MethodName: "Cancel",
+			Handler:    _Ticketing_Cancel_Handler,
+		},
+		{
+			MethodName: "ListAvailable",
+			Handler:    _Ticketing_ListAvailable_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "ticketing.proto",
+}
@@ -0,0 +1,452 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// 예매 전략
+const (
+	strategyPessimistic = "pessimistic" // SELECT ... FOR UPDATE 로 행을 잠근 뒤 UPDATE
+	strategyOptimistic  = "optimistic"  // 잠금 없이 조건부 UPDATE 후 영향받은 행 수로 판정
+	strategyAutocommit  = "autocommit"  // optimistic 과 같은 조건부 UPDATE 를 Begin/Commit 없이 한 문장으로 실행
+)
+
+// 예매 시도 결과
+type reserveOutcome int
+
+const (
+	reserveOK reserveOutcome = iota
+	reserveNotFound
+	reserveConflict
+	reserveLockTimeout
+	reserveDisabled
+	reserveNotOwned
+	reserveReplayed // 같은 nonce 로 이미 성공한 예매의 재요청
+	reserveSoldOut  // MAX_TOTAL_RESERVATIONS 상한 도달
+)
+
+// 예매 중 DB 오류 (Stage 는 로그 status 로 쓴다)
+type reserveError struct {
+	Stage string
+	Err   error
+}
+
+func (e *reserveError) Error() string { return e.Stage + ": " + e.Err.Error() }
+func (e *reserveError) Unwrap() error { return e.Err }
+
+// 오류를 로그용 status 와 원인으로 분리
+func splitReserveError(err error) (string, error) {
+	var rerr *reserveError
+	if errors.As(err, &rerr) {
+		return rerr.Stage, rerr.Err
+	}
+	return "reserve_fail", err
+}
+
+// TX_ISOLATION 값을 격리 수준으로 변환 (빈 값이면 서버 기본값, MySQL 은 REPEATABLE READ)
+func parseIsolation(s string) (sql.IsolationLevel, error) {
+	switch strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "_", " ")) {
+	case "":
+		return sql.LevelDefault, nil
+	case "READ COMMITTED":
+		return sql.LevelReadCommitted, nil
+	case "REPEATABLE READ":
+		return sql.LevelRepeatableRead, nil
+	case "SERIALIZABLE":
+		return sql.LevelSerializable, nil
+	}
+	return 0, fmt.Errorf("must be READ COMMITTED, REPEATABLE READ or SERIALIZABLE, got %q", s)
+}
+
+// 예매 트랜잭션 시작 (TX_ISOLATION 격리 수준 적용)
+func beginReserveTx() (*sql.Tx, error) {
+	level, _ := parseIsolation(cfg.TxIsolation) // loadConfig 에서 검증됨
+	return db.BeginTx(context.Background(), &sql.TxOptions{Isolation: level})
+}
+
+// 설정된 전략으로 좌석 한 개 예매, 성공하면 예매 순번을 함께 돌려준다
+// code 와 reservedAt 은 호출한 쪽에서 정해 넘기고, 성공 응답에 그대로 쓴다
+// nonce 가 있으면 좌석에 함께 저장하고, UNIQUE 제약으로 같은 nonce 의 재요청을 원래 성공으로 돌려준다
+func reserveSeat(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	var outcome reserveOutcome
+	var seq int64
+	var err error
+	switch cfg.ReserveStrategy {
+	case strategyOptimistic:
+		outcome, seq, err = reserveSeatOptimistic(userID, seatID, nonce, operatorID, code, reservedAt)
+	case strategyAutocommit:
+		outcome, seq, err = reserveSeatAutocommit(userID, seatID, nonce, operatorID, code, reservedAt)
+	default:
+		outcome, seq, err = reserveSeatPessimistic(userID, seatID, nonce, operatorID, code, reservedAt)
+	}
+	if nonce != "" && isDuplicateKey(err) {
+		return findReplayedReservation(userID, nonce)
+	}
+	// 상한에 닿은 뒤 들어온 재요청도 원래 성공으로 돌려준다
+	if nonce != "" && outcome == reserveSoldOut {
+		if replayed, seq, err := findReplayedReservation(userID, nonce); err != nil || replayed == reserveReplayed {
+			return replayed, seq, err
+		}
+	}
+	return outcome, seq, err
+}
+
+// 빈 nonce 는 NULL 로 저장 (UNIQUE 제약 대상에서 제외)
+func nullableNonce(nonce string) any {
+	if nonce == "" {
+		return nil
+	}
+	return nonce
+}
+
+// 직원 대리 예매가 아니면 (0) NULL 로 저장
+func nullableOperator(operatorID int) any {
+	if operatorID == 0 {
+		return nil
+	}
+	return operatorID
+}
+
+// 이미 같은 nonce 로 예매한 좌석이 이 사용자의 것인지 확인
+func findReplayedReservation(userID int, nonce string) (reserveOutcome, int64, error) {
+	var owner, seq sql.NullInt64
+	err := db.QueryRow(`SELECT user_id, reservation_seq FROM seats WHERE nonce = ?`, nonce).Scan(&owner, &seq)
+	if err == sql.ErrNoRows {
+		return reserveConflict, 0, nil // 그 사이 취소됨
+	} else if err != nil {
+		return 0, 0, &reserveError{"nonce_lookup_fail", err}
+	}
+	if !owner.Valid || int(owner.Int64) != userID {
+		return reserveConflict, 0, nil
+	}
+	return reserveReplayed, seq.Int64, nil
+}
+
+// 예매 순번 발급 (AUTO_INCREMENT 라 동시 요청에서도 겹치지 않고 증가)
+// 롤백된 트랜잭션의 번호는 재사용되지 않아 빈 번호가 생길 수 있다
+func nextReservationSeq(tx *sql.Tx, userID, seatID int) (int64, error) {
+	res, err := tx.Exec(`INSERT INTO reservation_sequence (seat_id, user_id) VALUES (?, ?)`, seatID, userID)
+	if err != nil {
+		return 0, err
+	}
+	return res.LastInsertId()
+}
+
+// 트랜잭션 안에서 예매한 좌석 한 개
+type reservedSeat struct {
+	SeatID     int
+	Seq        int64
+	Code       string // 저장된 확인 코드 (응답에는 formatConfirmationCode 로)
+	ReservedAt time.Time
+}
+
+// 저장하고 응답에 쓰는 예매 시각 (모든 예매 경로 공용, DATETIME 정밀도에 맞춤)
+// DB 의 NOW() 대신 이 값을 저장해야 응답, 웹훅, 저장된 값이 같아진다
+func newReservedAt() time.Time {
+	return time.Now().UTC().Truncate(time.Second)
+}
+
+// 잠가 둔 빈 좌석들을 userID 로 예매 (좌석 여러 개를 다루는 핸들러 공용)
+// 좌석 행은 FOR UPDATE 로 잠겨 있고 상한도 확인된 상태여야 한다, 커밋은 호출한 쪽에서
+func reserveSeatsTx(tx *sql.Tx, userID int, seatIDs []int) ([]reservedSeat, error) {
+	reserved := make([]reservedSeat, 0, len(seatIDs))
+	reservedAt := newReservedAt()
+	for _, id := range seatIDs {
+		code := newConfirmationCode()
+		seq, err := nextReservationSeq(tx, userID, id)
+		if err != nil {
+			return nil, &reserveError{"seq_fail", fmt.Errorf("seat %d: %w", id, err)}
+		}
+		_, err = tx.Exec(`UPDATE seats SET status = ?, user_id = ?, reserved_at = ?, reservation_seq = ?, confirmation_code = ? WHERE seat_id = ?`, SeatReserved, userID, reservedAt, seq, code, id)
+		if err != nil {
+			return nil, &reserveError{"update_fail", fmt.Errorf("seat %d: %w", id, err)}
+		}
+		reserved = append(reserved, reservedSeat{SeatID: id, Seq: seq, Code: code, ReservedAt: reservedAt})
+	}
+	return reserved, nil
+}
+
+// seat_id -> 응답용 확인 코드
+func confirmationCodes(reserved []reservedSeat) map[int]string {
+	codes := make(map[int]string, len(reserved))
+	for _, s := range reserved {
+		codes[s.SeatID] = formatConfirmationCode(s.Code)
+	}
+	return codes
+}
+
+// 예매가 커밋된 뒤의 후속 처리 (모든 예매 경로 공용), reservedAt 은 저장한 예매 시각
+func afterReserve(userID int, seatIDs []int, reservedAt time.Time) {
+	for _, id := range seatIDs {
+		sendWebhook(userID, id, reservedAt)
+		notifier.NotifyReservation(userID, id)
+		countSectionReservation(id)
+	}
+	seatCounts.move(SeatAvailable, SeatReserved, len(seatIDs))
+	invalidateSeatCache()
+}
+
+// MySQL 1062: Duplicate entry
+func isDuplicateKey(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == 1062
+}
+
+func reserveSeatPessimistic(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	tx, err := beginReserveTx()
+	if err != nil {
+		return 0, 0, &reserveError{"tx_begin_fail", err}
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		return 0, 0, &reserveError{"lock_timeout_set_fail", err}
+	}
+
+	var status SeatStatus
+	var storedNonce sql.NullString
+	var storedSeq sql.NullInt64
+	err = tx.QueryRow(`SELECT status, nonce, reservation_seq FROM seats WHERE seat_id = ? FOR UPDATE`, seatID).Scan(&status, &storedNonce, &storedSeq)
+	if err == sql.ErrNoRows {
+		return reserveNotFound, 0, nil
+	} else if isLockWaitTimeout(err) {
+		return reserveLockTimeout, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"select_fail", err}
+	}
+
+	if status == SeatDisabled {
+		return reserveDisabled, 0, nil
+	} else if status != SeatAvailable {
+		if nonce != "" && storedNonce.String == nonce {
+			return reserveReplayed, storedSeq.Int64, nil
+		}
+		return reserveConflict, 0, nil
+	}
+
+	left, err := reservationsLeft(tx)
+	if isLockWaitTimeout(err) {
+		return reserveLockTimeout, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"cap_check_fail", err}
+	}
+	if left == 0 {
+		return reserveSoldOut, 0, nil
+	}
+
+	seq, err := nextReservationSeq(tx, userID, seatID)
+	if err != nil {
+		return 0, 0, &reserveError{"seq_fail", err}
+	}
+
+	_, err = tx.Exec(`UPDATE seats SET status = ?, user_id = ?, reserved_at = ?, nonce = ?, reservation_seq = ?, operator_id = ?, confirmation_code = ? WHERE seat_id = ?`, SeatReserved, userID, reservedAt, nullableNonce(nonce), seq, nullableOperator(operatorID), code, seatID)
+	if err != nil {
+		return 0, 0, &reserveError{"update_fail", err}
+	}
+
+	artificialDelay()
+	if err := tx.Commit(); err != nil {
+		return 0, 0, &reserveError{"commit_fail", err}
+	}
+	return reserveOK, seq, nil
+}
+
+func reserveSeatOptimistic(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	tx, err := beginReserveTx()
+	if err != nil {
+		return 0, 0, &reserveError{"tx_begin_fail", err}
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		return 0, 0, &reserveError{"lock_timeout_set_fail", err}
+	}
+
+	seq, err := nextReservationSeq(tx, userID, seatID)
+	if err != nil {
+		return 0, 0, &reserveError{"seq_fail", err}
+	}
+
+	res, err := tx.Exec(`UPDATE seats SET status = ?, user_id = ?, reserved_at = ?, nonce = ?, reservation_seq = ?, operator_id = ?, confirmation_code = ? WHERE seat_id = ? AND status = ?`, SeatReserved, userID, reservedAt, nullableNonce(nonce), seq, nullableOperator(operatorID), code, seatID, SeatAvailable)
+	if isLockWaitTimeout(err) {
+		return reserveLockTimeout, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"update_fail", err}
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, &reserveError{"update_fail", err}
+	}
+
+	if n == 0 {
+		// 없는 좌석인지, 판매 중지 좌석인지, 이미 예매된 좌석인지 구분
+		var status SeatStatus
+		var storedNonce sql.NullString
+		var storedSeq sql.NullInt64
+		err := tx.QueryRow(`SELECT status, nonce, reservation_seq FROM seats WHERE seat_id = ?`, seatID).Scan(&status, &storedNonce, &storedSeq)
+		if err == sql.ErrNoRows {
+			return reserveNotFound, 0, nil
+		} else if err != nil {
+			return 0, 0, &reserveError{"select_fail", err}
+		}
+		if status == SeatDisabled {
+			return reserveDisabled, 0, nil
+		}
+		if nonce != "" && storedNonce.String == nonce {
+			return reserveReplayed, storedSeq.Int64, nil
+		}
+		return reserveConflict, 0, nil
+	}
+
+	// 좌석 행을 잠근 뒤에 상한 확인 (롤백하면 UPDATE 도 취소됨)
+	exceeded, err := reservationCapExceeded(tx)
+	if isLockWaitTimeout(err) {
+		return reserveLockTimeout, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"cap_check_fail", err}
+	}
+	if exceeded {
+		return reserveSoldOut, 0, nil
+	}
+
+	artificialDelay()
+	if err := tx.Commit(); err != nil {
+		return 0, 0, &reserveError{"commit_fail", err}
+	}
+	return reserveOK, seq, nil
+}
+
+// 명시적 트랜잭션 없이 조건부 UPDATE 한 문장으로 예매 (트랜잭션 왕복 비용 측정용)
+// 한 문장을 유지하기 위해 예매 순번은 발급하지 않고 (0), TX_ISOLATION, LOCK_WAIT_TIMEOUT_SEC,
+// ARTIFICIAL_DELAY_MS 도 적용되지 않는다. MAX_TOTAL_RESERVATIONS 는 잠금 없이 확인해 조금 넘을 수 있다
+func reserveSeatAutocommit(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	if left, err := countReservationsLeft(db); err != nil {
+		return 0, 0, &reserveError{"cap_check_fail", err}
+	} else if left == 0 {
+		return reserveSoldOut, 0, nil
+	}
+
+	res, err := db.Exec(`UPDATE seats SET status = ?, user_id = ?, reserved_at = ?, nonce = ?, operator_id = ?, confirmation_code = ? WHERE seat_id = ? AND status = ?`, SeatReserved, userID, reservedAt, nullableNonce(nonce), nullableOperator(operatorID), code, seatID, SeatAvailable)
+	if isLockWaitTimeout(err) {
+		return reserveLockTimeout, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"update_fail", err}
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, 0, &reserveError{"update_fail", err}
+	}
+	if n == 1 {
+		return reserveOK, 0, nil
+	}
+
+	// 없는 좌석인지, 판매 중지 좌석인지, 이미 예매된 좌석인지 구분
+	var status SeatStatus
+	var storedNonce sql.NullString
+	var storedSeq sql.NullInt64
+	err = db.QueryRow(`SELECT status, nonce, reservation_seq FROM seats WHERE seat_id = ?`, seatID).Scan(&status, &storedNonce, &storedSeq)
+	if err == sql.ErrNoRows {
+		return reserveNotFound, 0, nil
+	} else if err != nil {
+		return 0, 0, &reserveError{"select_fail", err}
+	}
+	if status == SeatDisabled {
+		return reserveDisabled, 0, nil
+	}
+	if nonce != "" && storedNonce.String == nonce {
+		return reserveReplayed, storedSeq.Int64, nil
+	}
+	return reserveConflict, 0, nil
+}
+
+// 느린 백엔드 흉내 (ARTIFICIAL_DELAY_MS, 커밋 직전 호출)
+func artificialDelay() {
+	if cfg.ArtificialDelayMS > 0 {
+		time.Sleep(time.Duration(cfg.ArtificialDelayMS) * time.Millisecond)
+	}
+}
+
+// 사용자가 예매한 좌석 한 개 취소
+func cancelSeat(userID, seatID int) (reserveOutcome, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return 0, &reserveError{"tx_begin_fail", err}
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		return 0, &reserveError{"lock_timeout_set_fail", err}
+	}
+
+	var status SeatStatus
+	var owner sql.NullInt64
+	err = tx.QueryRow(`SELECT status, user_id FROM seats WHERE seat_id = ? FOR UPDATE`, seatID).Scan(&status, &owner)
+	if err == sql.ErrNoRows {
+		return reserveNotFound, nil
+	} else if isLockWaitTimeout(err) {
+		return reserveLockTimeout, nil
+	} else if err != nil {
+		return 0, &reserveError{"select_fail", err}
+	}
+
+	if status != SeatReserved || !owner.Valid || int(owner.Int64) != userID {
+		return reserveNotOwned, nil
+	}
+
+	_, err = tx.Exec(`UPDATE seats SET status = ?, user_id = NULL, reserved_at = NULL, nonce = NULL, reservation_seq = NULL, operator_id = NULL, confirmation_code = NULL WHERE seat_id = ?`, SeatAvailable, seatID)
+	if err != nil {
+		return 0, &reserveError{"update_fail", err}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, &reserveError{"commit_fail", err}
+	}
+	return reserveOK, nil
+}
+
+// 사용자의 모든 예매 취소, 풀린 좌석 ID 를 돌려준다 (단건 취소와 같이 행을 잠근 뒤 갱신)
+func cancelAllSeats(userID int) ([]int, reserveOutcome, error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, 0, &reserveError{"tx_begin_fail", err}
+	}
+	defer tx.Rollback()
+
+	if err := setLockWaitTimeout(tx); err != nil {
+		return nil, 0, &reserveError{"lock_timeout_set_fail", err}
+	}
+
+	rows, err := tx.Query(`SELECT seat_id FROM seats WHERE user_id = ? AND status = ? ORDER BY seat_id FOR UPDATE`, userID, SeatReserved)
+	if isLockWaitTimeout(err) {
+		return nil, reserveLockTimeout, nil
+	} else if err != nil {
+		return nil, 0, &reserveError{"select_fail", err}
+	}
+	freed := make([]int, 0)
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err == nil {
+			freed = append(freed, id)
+		}
+	}
+	rows.Close()
+
+	if len(freed) > 0 {
+		_, err = tx.Exec(`UPDATE seats SET status = ?, user_id = NULL, reserved_at = NULL, nonce = NULL, reservation_seq = NULL, operator_id = NULL, confirmation_code = NULL WHERE user_id = ? AND status = ?`, SeatAvailable, userID, SeatReserved)
+		if err != nil {
+			return nil, 0, &reserveError{"update_fail", err}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, 0, &reserveError{"commit_fail", err}
+	}
+	return freed, reserveOK, nil
+}
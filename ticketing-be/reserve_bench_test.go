@@ -0,0 +1,237 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand/v2"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+const benchSeatCount = 1000
+
+// TEST_MYSQL_DSN 으로 지정한 MySQL 에 연결 (없으면 건너뜀)
+// 예: TEST_MYSQL_DSN='root:password@tcp(127.0.0.1:3306)/ticketing?parseTime=true'
+func setupTestDB(tb testing.TB) {
+	tb.Helper()
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		tb.Skip("TEST_MYSQL_DSN not set")
+	}
+
+	log.SetOutput(io.Discard)
+	saved := cfg
+	tb.Cleanup(func() { cfg = saved }) // 하위 벤치마크가 바꾼 전략, 격리 수준 등을 되돌림
+	var err error
+	cfg, err = loadConfig()
+	if err != nil {
+		tb.Fatalf("config: %v", err)
+	}
+	cfg.SeatCount = benchSeatCount
+
+	db, err = sql.Open("mysql", dsn)
+	if err != nil {
+		tb.Fatalf("open: %v", err)
+	}
+	db.SetMaxOpenConns(256)
+	db.SetMaxIdleConns(256)
+	readDB = db
+	tb.Cleanup(func() { db.Close() })
+
+	if err := initSeats(cfg.SeatCount); err != nil {
+		tb.Fatalf("initSeats: %v", err)
+	}
+	resetSeats(tb)
+}
+
+// 모든 좌석을 빈 좌석으로 되돌림
+func resetSeats(tb testing.TB) {
+	tb.Helper()
+	if _, err := db.Exec(`UPDATE seats SET status = ?, user_id = NULL, reserved_at = NULL, nonce = NULL, reservation_seq = NULL, operator_id = NULL, confirmation_code = NULL`, SeatAvailable); err != nil {
+		tb.Fatalf("reset: %v", err)
+	}
+}
+
+// b.N 번의 예매를 workers 개 고루틴으로 나눠 실행, reserve 가 false 를 돌려주면 그 고루틴은 멈춘다
+// 좌석이 바닥나 충돌 경로만 재지 않도록 benchSeatCount 번마다 타이머를 멈추고 reset 으로 좌석을 되돌린다
+func runReserveRounds(b *testing.B, workers int, reset func(), reserve func(userID int) bool) {
+	b.ResetTimer()
+	b.StopTimer()
+	for done := 0; done < b.N; done += benchSeatCount {
+		reset()
+		n := int64(min(benchSeatCount, b.N-done))
+
+		var (
+			next atomic.Int64
+			wg   sync.WaitGroup
+		)
+		b.StartTimer()
+		for w := 0; w < workers; w++ {
+			wg.Add(1)
+			go func(userID int) {
+				defer wg.Done()
+				for next.Add(1) <= n {
+					if !reserve(userID) {
+						return
+					}
+				}
+			}(w + 1)
+		}
+		wg.Wait()
+		b.StopTimer()
+	}
+}
+
+// 전략별, 동시성별 예매 처리량 비교
+//
+//	TEST_MYSQL_DSN=... go test -run '^$' -bench BenchmarkReserve
+func BenchmarkReserve(b *testing.B) {
+	setupTestDB(b)
+
+	for _, strategy := range []string{strategyPessimistic, strategyOptimistic, strategyAutocommit} {
+		for _, workers := range []int{1, 16, 64, 256} {
+			b.Run(fmt.Sprintf("%s/workers=%d", strategy, workers), func(b *testing.B) {
+				cfg.ReserveStrategy = strategy
+
+				var conflicts atomic.Int64
+				runReserveRounds(b, workers, func() { resetSeats(b) }, func(userID int) bool {
+					outcome, _, err := reserveSeat(userID, rand.IntN(benchSeatCount)+1, "", 0, newConfirmationCode(), time.Now())
+					if err != nil {
+						b.Error(err)
+						return false
+					}
+					if outcome != reserveOK {
+						conflicts.Add(1)
+					}
+					return true
+				})
+
+				b.ReportMetric(float64(conflicts.Load())/float64(b.N), "conflicts/op")
+			})
+		}
+	}
+}
+
+// 격리 수준별 예매 처리량과 교착 상태 비교 (CLI 처럼 여러 사용자가 무작위 좌석을 동시에 예매)
+// 교착 상태 (MySQL 1213) 는 오류로 끝내지 않고 세어 deadlocks/op 로 보고한다
+//
+//	TEST_MYSQL_DSN=... go test -run '^$' -bench BenchmarkReserveIsolation
+func BenchmarkReserveIsolation(b *testing.B) {
+	setupTestDB(b)
+	const workers = 64
+
+	for _, isolation := range []string{"READ COMMITTED", "REPEATABLE READ"} {
+		for _, strategy := range []string{strategyPessimistic, strategyOptimistic} {
+			b.Run(fmt.Sprintf("%s/%s", strings.ReplaceAll(isolation, " ", "_"), strategy), func(b *testing.B) {
+				cfg.TxIsolation = isolation
+				cfg.ReserveStrategy = strategy
+
+				var (
+					conflicts atomic.Int64
+					deadlocks atomic.Int64
+				)
+				runReserveRounds(b, workers, func() { resetSeats(b) }, func(userID int) bool {
+					outcome, _, err := reserveSeat(userID, rand.IntN(benchSeatCount)+1, "", 0, newConfirmationCode(), time.Now())
+					if isDeadlock(err) {
+						deadlocks.Add(1)
+						return true
+					} else if err != nil {
+						b.Error(err)
+						return false
+					}
+					if outcome != reserveOK {
+						conflicts.Add(1)
+					}
+					return true
+				})
+
+				b.ReportMetric(float64(conflicts.Load())/float64(b.N), "conflicts/op")
+				b.ReportMetric(float64(deadlocks.Load())/float64(b.N), "deadlocks/op")
+			})
+		}
+	}
+}
+
+// 인기 좌석 몇 개에 요청이 몰릴 때 SEAT_FIFO 큐의 처리량 비용
+// 첫 예매 뒤로는 대부분 충돌이라 잠금 경쟁과 충돌 응답 경로를 주로 잰다
+//
+//	TEST_MYSQL_DSN=... go test -run '^$' -bench BenchmarkReserveHotSeat
+func BenchmarkReserveHotSeat(b *testing.B) {
+	setupTestDB(b)
+	const (
+		workers  = 64
+		hotSeats = 4
+	)
+
+	for _, fifo := range []bool{false, true} {
+		b.Run(fmt.Sprintf("fifo=%v", fifo), func(b *testing.B) {
+			cfg.SeatFIFO = fifo
+			resetSeats(b)
+
+			var (
+				next atomic.Int64
+				wg   sync.WaitGroup
+			)
+			b.ResetTimer()
+			for w := 0; w < workers; w++ {
+				wg.Add(1)
+				go func(userID int) {
+					defer wg.Done()
+					for next.Add(1) <= int64(b.N) {
+						if _, _, err := reserveInOrder(userID, rand.IntN(hotSeats)+1, "", 0, newConfirmationCode(), time.Now()); err != nil {
+							b.Error(err)
+							return
+						}
+					}
+				}(w + 1)
+			}
+			wg.Wait()
+		})
+	}
+}
+
+// DB 없이 memStore 로 예매 경로 자체 (차단기, SEAT_FIFO 큐, 저장소 호출) 의 비용과 충돌률
+// MySQL 벤치마크 결과에서 이 값을 빼면 DB 에서 쓴 시간을 가늠할 수 있다
+//
+//	go test -run '^$' -bench BenchmarkReserveMemStore
+func BenchmarkReserveMemStore(b *testing.B) {
+	mem := useMemStore(b, benchSeatCount)
+
+	for _, fifo := range []bool{false, true} {
+		for _, workers := range []int{1, 16, 64, 256} {
+			b.Run(fmt.Sprintf("fifo=%v/workers=%d", fifo, workers), func(b *testing.B) {
+				cfg.SeatFIFO = fifo
+
+				var conflicts atomic.Int64
+				runReserveRounds(b, workers, mem.reset, func(userID int) bool {
+					outcome, _, err := reserveInOrder(userID, rand.IntN(benchSeatCount)+1, "", 0, newConfirmationCode(), time.Now())
+					if err != nil {
+						b.Error(err)
+						return false
+					}
+					if outcome != reserveOK {
+						conflicts.Add(1)
+					}
+					return true
+				})
+
+				b.ReportMetric(float64(conflicts.Load())/float64(b.N), "conflicts/op")
+			})
+		}
+	}
+}
+
+// MySQL 1213: Deadlock found when trying to get lock
+func isDeadlock(err error) bool {
+	var myErr *mysql.MySQLError
+	return errors.As(err, &myErr) && myErr.Number == 1213
+}
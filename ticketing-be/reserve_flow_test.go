@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+)
+
+// 실제 라우팅으로 서버를 띄워 조회 → 예매 → 충돌 → 취소 흐름과 저장소 상태 확인 (memStore, DB 없이)
+func TestReserveFlow(t *testing.T) {
+	const seats = 10
+	mem := useMemStore(t, seats)
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	runReserveFlow(t, srv.URL, seats, func(seatID int) (SeatStatus, int) {
+		mem.mu.Lock()
+		defer mem.mu.Unlock()
+		if owner := mem.owner[seatID]; owner != 0 {
+			return SeatReserved, owner
+		}
+		return SeatAvailable, 0
+	})
+}
+
+// 같은 흐름을 MySQL 에서
+//
+//	TEST_MYSQL_DSN=... go test -run TestReserveFlowMySQL
+func TestReserveFlowMySQL(t *testing.T) {
+	srv := newTestServer(t)
+
+	runReserveFlow(t, srv.URL, benchSeatCount, func(seatID int) (SeatStatus, int) {
+		var status SeatStatus
+		var owner sql.NullInt64
+		if err := db.QueryRow(`SELECT status, user_id FROM seats WHERE seat_id = ?`, seatID).Scan(&status, &owner); err != nil {
+			t.Fatalf("select: %v", err)
+		}
+		return status, int(owner.Int64)
+	})
+}
+
+// 좌석 seats 개가 모두 빈 상태에서 시작해 단계마다 응답 코드와 seatState 가 돌려주는 좌석 상태/주인 확인
+func runReserveFlow(t *testing.T, baseURL string, seats int, seatState func(seatID int) (SeatStatus, int)) {
+	t.Helper()
+	const seatID = 1
+	if available := getAvailable(t, baseURL); len(available) != seats || !slices.Contains(available, seatID) {
+		t.Fatalf("available: got %d seats, want %d including seat %d", len(available), seats, seatID)
+	}
+
+	steps := []struct {
+		name      string
+		path      string
+		userID    int
+		target    int
+		wantCode  int
+		wantOwner int // seatID 의 주인, 0 이면 빈 좌석이어야 함
+	}{
+		{"reserve", "/reserve", 1, seatID, http.StatusOK, 1},
+		{"conflict", "/reserve", 2, seatID, http.StatusConflict, 1},
+		{"unknown seat", "/reserve", 2, seats + 1, http.StatusNotFound, 1},
+		{"cancel by other user", "/reserve/cancel", 2, seatID, http.StatusConflict, 1},
+		{"cancel", "/reserve/cancel", 1, seatID, http.StatusOK, 0},
+		{"reserve after cancel", "/reserve", 2, seatID, http.StatusOK, 2},
+	}
+	for _, step := range steps {
+		code := postJSON(t, baseURL+step.path, TicketRequest{UserID: step.userID, SeatID: step.target})
+		if code != step.wantCode {
+			t.Fatalf("%s: status %d, want %d", step.name, code, step.wantCode)
+		}
+
+		status, owner := seatState(seatID)
+		wantStatus := SeatReserved
+		if step.wantOwner == 0 {
+			wantStatus = SeatAvailable
+		}
+		if status != wantStatus || owner != step.wantOwner {
+			t.Fatalf("%s: seat is %s/%d, want %s/%d", step.name, status, owner, wantStatus, step.wantOwner)
+		}
+
+		// 예매 중인 좌석은 빈 좌석 목록에 없어야 함 (캐시 무효화 확인)
+		if got := slices.Contains(getAvailable(t, baseURL), seatID); got != (step.wantOwner == 0) {
+			t.Fatalf("%s: seat %d listed as available = %v", step.name, seatID, got)
+		}
+	}
+}
+
+// 테스트 DB 에 연결하고 인증, 장애 주입, 예매 상한을 끈 서버를 띄움
+func newTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	setupTestDB(t)
+	cfg.JWTSecret = ""
+	cfg.FaultInjectRate = 0
+	cfg.MaxReservations = 0
+	reserveBreaker = newCircuitBreaker("reserve", cfg.BreakerThreshold, cfg.BreakerCooldown)
+	invalidateSeatCache()
+
+	srv := httptest.NewServer(newMux())
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func getAvailable(t *testing.T, baseURL string) []int {
+	t.Helper()
+	resp, err := http.Get(baseURL + "/seats/available")
+	if err != nil {
+		t.Fatalf("available: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("available: status %d", resp.StatusCode)
+	}
+	var seats []int
+	if err := json.NewDecoder(resp.Body).Decode(&seats); err != nil {
+		t.Fatalf("available: %v", err)
+	}
+	return seats
+}
+
+func postJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	body, _ := json.Marshal(v)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("%s: %v", url, err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
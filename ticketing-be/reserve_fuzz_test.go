@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// 예매 요청 JSON 파싱 퍼징
+// JWT_SECRET 을 켜 두어 파싱을 통과한 요청은 토큰 검사 (401) 에서 끝나므로 DB 없이 돌아간다
+//
+//	go test -run '^$' -fuzz FuzzReserveRequest
+func FuzzReserveRequest(f *testing.F) {
+	log.SetOutput(io.Discard)
+	cfg = Config{JWTSecret: "fuzz"}
+
+	for _, seed := range []string{
+		`{"user_id":1,"seat_id":2}`,
+		`{"user_id":1,"seat_id":2,"nonce":"abc"}`,
+		`{"user_id":"1"}`,
+		`{"user_id":1e40}`,
+		`[1,2]`,
+		`{`,
+		``,
+		`null`,
+	} {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, body []byte) {
+		req := httptest.NewRequest(http.MethodPost, "/reserve", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		reserveHandler(rec, req)
+
+		// 핸들러와 같은 방식 (첫 값만 읽음) 으로 기대 결과 계산
+		var parsed TicketRequest
+		err := json.NewDecoder(bytes.NewReader(body)).Decode(&parsed)
+		want := http.StatusUnauthorized
+		if err != nil || len(parsed.Nonce) > maxNonceLength {
+			want = http.StatusBadRequest
+		}
+		if rec.Code != want {
+			t.Fatalf("body %q: status %d, want %d", body, rec.Code, want)
+		}
+	})
+}
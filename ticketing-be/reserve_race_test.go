@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+)
+
+// 같은 좌석에 동시에 예매를 보내면 정확히 한 명만 성공하고 나머지는 409 (memStore, DB 없이)
+func TestReserveSameSeatRace(t *testing.T) {
+	mem := useMemStore(t, 10)
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	runSameSeatRace(t, srv.URL, func(seatID int) int {
+		mem.mu.Lock()
+		defer mem.mu.Unlock()
+		return mem.owner[seatID]
+	})
+}
+
+// 같은 경쟁을 MySQL 에서 전략별로
+//
+//	TEST_MYSQL_DSN=... go test -run TestReserveSameSeatRaceMySQL
+func TestReserveSameSeatRaceMySQL(t *testing.T) {
+	srv := newTestServer(t)
+
+	for _, strategy := range []string{strategyPessimistic, strategyOptimistic, strategyAutocommit} {
+		t.Run(strategy, func(t *testing.T) {
+			cfg.ReserveStrategy = strategy
+			resetSeats(t)
+			runSameSeatRace(t, srv.URL, func(seatID int) int {
+				var owner sql.NullInt64
+				if err := db.QueryRow(`SELECT user_id FROM seats WHERE seat_id = ?`, seatID).Scan(&owner); err != nil {
+					t.Fatalf("select: %v", err)
+				}
+				return int(owner.Int64)
+			})
+		})
+	}
+}
+
+// players 명이 좌석 1 에 동시에 예매하고, 응답상 승자가 한 명이며 ownerOf 가 돌려주는 좌석 주인과 같은지 확인
+func runSameSeatRace(t *testing.T, baseURL string, ownerOf func(seatID int) int) {
+	t.Helper()
+	const (
+		seatID  = 1
+		players = 200
+	)
+
+	// 모든 goroutine 이 준비된 뒤 한꺼번에 출발
+	var (
+		ready, wg sync.WaitGroup
+		start     = make(chan struct{})
+		codes     = make([]int, players)
+	)
+	for i := range players {
+		ready.Add(1)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ready.Done()
+			<-start
+			// postJSON 의 t.Fatalf 는 테스트 goroutine 에서만 쓸 수 있어 직접 보냄
+			body, _ := json.Marshal(TicketRequest{UserID: i + 1, SeatID: seatID})
+			resp, err := http.Post(baseURL+"/reserve", "application/json", bytes.NewReader(body))
+			if err != nil {
+				t.Errorf("user %d: %v", i+1, err)
+				return
+			}
+			resp.Body.Close()
+			codes[i] = resp.StatusCode
+		}()
+	}
+	ready.Wait()
+	close(start)
+	wg.Wait()
+	if t.Failed() {
+		t.FailNow()
+	}
+
+	winner := 0
+	for i, code := range codes {
+		switch code {
+		case http.StatusOK:
+			if winner != 0 {
+				t.Fatalf("users %d and %d both reserved seat %d", winner, i+1, seatID)
+			}
+			winner = i + 1
+		case http.StatusConflict:
+		default:
+			t.Fatalf("user %d: status %d, want 200 or 409", i+1, code)
+		}
+	}
+	if winner == 0 {
+		t.Fatalf("no user reserved seat %d", seatID)
+	}
+
+	// 응답상 승자와 저장된 좌석 주인이 같아야 함
+	if owner := ownerOf(seatID); owner != winner {
+		t.Fatalf("seat %d owned by %d, but user %d got 200", seatID, owner, winner)
+	}
+}
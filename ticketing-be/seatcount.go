@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"sync/atomic"
+	"time"
+)
+
+// 상태별 좌석 수 메모리 집계 (/seats/count 폴링마다 COUNT(*) 를 돌리지 않도록)
+// 기동 시 DB 에서 읽고, 예매/취소/관리자 변경이 성공할 때마다 옮겨 적는다
+// 다른 인스턴스나 직접 실행한 SQL 로 바뀐 것은 알 수 없으므로 주기적으로 DB 와 맞춘다
+type seatCounter struct {
+	ready     atomic.Bool
+	total     atomic.Int64
+	available atomic.Int64
+	reserved  atomic.Int64
+	disabled  atomic.Int64
+}
+
+var seatCounts seatCounter
+
+func (c *seatCounter) byStatus(s SeatStatus) *atomic.Int64 {
+	switch s {
+	case SeatAvailable:
+		return &c.available
+	case SeatReserved:
+		return &c.reserved
+	case SeatDisabled:
+		return &c.disabled
+	}
+	return nil
+}
+
+// 좌석 n 개의 상태가 from 에서 to 로 바뀜
+func (c *seatCounter) move(from, to SeatStatus, n int) {
+	if !c.ready.Load() || n == 0 {
+		return
+	}
+	c.byStatus(from).Add(int64(-n))
+	c.byStatus(to).Add(int64(n))
+}
+
+// 현재 집계 (아직 DB 에서 읽기 전이거나 꺼져 있으면 ok=false)
+func (c *seatCounter) snapshot() (map[string]int, bool) {
+	if !c.ready.Load() {
+		return nil, false
+	}
+	return map[string]int{
+		"total":               int(c.total.Load()),
+		string(SeatAvailable): int(c.available.Load()),
+		string(SeatReserved):  int(c.reserved.Load()),
+		string(SeatDisabled):  int(c.disabled.Load()),
+	}, true
+}
+
+// DB 집계로 덮어쓰고, 메모리 값과 달랐으면 WARN 로그
+// 조회와 덮어쓰기 사이에 끝난 예매는 다음 맞춤에서 반영된다
+func (c *seatCounter) reconcile() error {
+	counts, err := store.Counts()
+	if err != nil {
+		return err
+	}
+	if old, ok := c.snapshot(); ok {
+		for k, v := range counts {
+			if old[k] != v {
+				logJSON("WARN", "seat_count", 0, 0, fmt.Sprintf("drift %s memory=%d db=%d", k, old[k], v), nil)
+			}
+		}
+	}
+	c.total.Store(int64(counts["total"]))
+	c.available.Store(int64(counts[string(SeatAvailable)]))
+	c.reserved.Store(int64(counts[string(SeatReserved)]))
+	c.disabled.Store(int64(counts[string(SeatDisabled)]))
+	c.ready.Store(true)
+	return nil
+}
+
+// 메모리 집계 시작 (interval 이 0 이면 끄고 /seats/count 가 매번 DB 를 조회)
+func startSeatCounter(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	if err := seatCounts.reconcile(); err != nil {
+		logJSON("ERROR", "seat_count", 0, 0, "init_fail", err)
+	}
+	go func() {
+		for range time.Tick(interval) {
+			if err := seatCounts.reconcile(); err != nil {
+				logJSON("ERROR", "seat_count", 0, 0, "reconcile_fail", err)
+			}
+		}
+	}()
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// 좌석 배치 도우미
+// SEAT_ROWS × SEAT_COLUMNS 가 설정되면 seat_id 는 행 우선으로 1 부터 매기고
+// 사람이 읽는 라벨은 행 문자 + 열 번호 (예: A1, B12, AA3) 로 만든다
+
+// 0 부터 시작하는 행 번호를 A, B, ..., Z, AA, AB, ... 로 변환
+func rowName(row int) string {
+	name := ""
+	for row >= 0 {
+		name = string(rune('A'+row%26)) + name
+		row = row/26 - 1
+	}
+	return name
+}
+
+// 좌석의 행/열 (0 부터) 과 라벨, 배치가 설정되지 않았으면 ok 는 false
+func seatPosition(seatID int) (row, col int, label string, ok bool) {
+	if cfg.SeatRows <= 0 || cfg.SeatColumns <= 0 {
+		return 0, 0, "", false
+	}
+	row = (seatID - 1) / cfg.SeatColumns
+	col = (seatID - 1) % cfg.SeatColumns
+	return row, col, rowName(row) + strconv.Itoa(col+1), true
+}
+
+// 좌석 구역 (seat_id 범위와 좌석 가격)
+type SeatSection struct {
+	Name  string `json:"name"`
+	From  int    `json:"from"`
+	To    int    `json:"to"`
+	Price int    `json:"price,omitempty"`
+}
+
+// 구역 설정에 포함되지 않은 좌석의 구역 이름
+const defaultSection = "general"
+
+// "VIP:1-100@150000,R:101-500@80000" 형식의 SEAT_SECTIONS 파싱 (@가격 은 생략 가능, 기본 0)
+func parseSections(s string) ([]SeatSection, error) {
+	var sections []SeatSection
+	if strings.TrimSpace(s) == "" {
+		return sections, nil
+	}
+	for _, part := range strings.Split(s, ",") {
+		name, span, ok := strings.Cut(strings.TrimSpace(part), ":")
+		span, price, hasPrice := strings.Cut(span, "@")
+		from, to, ok2 := strings.Cut(span, "-")
+		if !ok || !ok2 || name == "" {
+			return nil, fmt.Errorf("%q is not NAME:FROM-TO[@PRICE]", part)
+		}
+		sec := SeatSection{Name: name}
+		if hasPrice {
+			p, err := strconv.Atoi(price)
+			if err != nil || p < 0 {
+				return nil, fmt.Errorf("%q has an invalid price", part)
+			}
+			sec.Price = p
+		}
+		var err1, err2 error
+		sec.From, err1 = strconv.Atoi(from)
+		sec.To, err2 = strconv.Atoi(to)
+		if err1 != nil || err2 != nil || sec.From <= 0 || sec.To < sec.From {
+			return nil, fmt.Errorf("%q has an invalid seat range", part)
+		}
+		for _, other := range sections {
+			if sec.From <= other.To && other.From <= sec.To {
+				return nil, fmt.Errorf("%q overlaps section %s", part, other.Name)
+			}
+		}
+		sections = append(sections, sec)
+	}
+	return sections, nil
+}
+
+// 좌석이 속한 구역 이름
+func sectionFor(seatID int) string {
+	for _, sec := range cfg.Sections {
+		if seatID >= sec.From && seatID <= sec.To {
+			return sec.Name
+		}
+	}
+	return defaultSection
+}
+
+// SEAT_SECTIONS 에 있는 구역이거나 기본 구역인지
+func knownSection(name string) bool {
+	if name == defaultSection {
+		return true
+	}
+	for _, sec := range cfg.Sections {
+		if sec.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// 좌석 목록 중 구역에 속한 것만 새 슬라이스로 반환 (캐시된 원본은 건드리지 않음)
+func seatsInSection(seats []int, name string) []int {
+	var in []int
+	for _, id := range seats {
+		if sectionFor(id) == name {
+			in = append(in, id)
+		}
+	}
+	return in
+}
+
+// 좌석 가격 (구역에 속하지 않으면 0)
+func priceFor(seatID int) int {
+	for _, sec := range cfg.Sections {
+		if seatID >= sec.From && seatID <= sec.To {
+			return sec.Price
+		}
+	}
+	return 0
+}
+
+// 좌석 배치도의 좌석 한 개
+type SeatMapEntry struct {
+	SeatID  int        `json:"seat_id"`
+	Row     int        `json:"row"`
+	Col     int        `json:"col"`
+	Label   string     `json:"label,omitempty"`
+	Section string     `json:"section"`
+	Status  SeatStatus `json:"status"`
+}
+
+type SeatMap struct {
+	Rows    int            `json:"rows"`
+	Columns int            `json:"columns"`
+	Seats   []SeatMapEntry `json:"seats"`
+}
+
+// 배치가 설정되지 않았을 때 배치도에서 쓰는 열 수
+const defaultMapColumns = 50
+
+// 좌석 배치도 (행/열, 라벨, 구역, 상태) 반환
+// 배치가 설정되지 않았으면 seat_id 순서로 defaultMapColumns 열에 채운다
+func seatMapHandler(w http.ResponseWriter, r *http.Request) {
+	rows, err := readDB.Query(`SELECT seat_id, seat_row, seat_col, COALESCE(label, ''), section, status FROM seats ORDER BY seat_id`)
+	if isTableMissing(err) {
+		logJSON("ERROR", "seat_map", 0, 0, "not_initialized", err)
+		http.Error(w, "not_initialized", http.StatusServiceUnavailable)
+		return
+	} else if err != nil {
+		logJSON("ERROR", "seat_map", 0, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	defer rows.Close()
+
+	m := SeatMap{Rows: cfg.SeatRows, Columns: cfg.SeatColumns, Seats: []SeatMapEntry{}}
+	layout := m.Rows > 0 && m.Columns > 0
+	if !layout {
+		m.Columns = defaultMapColumns
+	}
+	for rows.Next() {
+		var e SeatMapEntry
+		var row, col *int
+		if err := rows.Scan(&e.SeatID, &row, &col, &e.Label, &e.Section, &e.Status); err != nil {
+			continue
+		}
+		if layout && row != nil && col != nil {
+			e.Row, e.Col = *row, *col
+		} else {
+			e.Row, e.Col = (e.SeatID-1)/m.Columns, (e.SeatID-1)%m.Columns
+		}
+		m.Seats = append(m.Seats, e)
+	}
+	if !layout && len(m.Seats) > 0 {
+		m.Rows = m.Seats[len(m.Seats)-1].Row + 1
+	}
+
+	logJSON("INFO", "seat_map", 0, 0, fmt.Sprintf("count=%d", len(m.Seats)), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "seat_map", 0, 0, m)
+}
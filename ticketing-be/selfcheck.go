@@ -0,0 +1,59 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// 코드가 사용하는 seats 컬럼
+var expectedSeatColumns = []string{
+	"seat_id", "status", "user_id", "reserved_at",
+	"seat_row", "seat_col", "label", "section", "nonce", "reservation_seq", "price", "operator_id", "confirmation_code",
+}
+
+// 기동 시 스키마 점검
+// CREATE TABLE IF NOT EXISTS 는 기존 테이블에 컬럼을 추가하지 않으므로
+// 예전 스키마가 남아 있으면 여기서 잡는다
+func selfCheck() error {
+	var errs []error
+
+	rows, err := db.Query(`SELECT COLUMN_NAME FROM information_schema.COLUMNS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'seats'`)
+	if err != nil {
+		return fmt.Errorf("columns: %w", err)
+	}
+	var columns []string
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err == nil {
+			columns = append(columns, name)
+		}
+	}
+	rows.Close()
+
+	if len(columns) == 0 {
+		return errors.New("seats table does not exist")
+	}
+	for _, col := range expectedSeatColumns {
+		if !slices.Contains(columns, col) {
+			errs = append(errs, fmt.Errorf("seats.%s is missing", col))
+		}
+	}
+
+	// 없으면 /cancel/all 이 테이블 전체를 훑으며 잠근다
+	var indexed int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM information_schema.STATISTICS WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = 'seats' AND INDEX_NAME = 'idx_user_status'`).Scan(&indexed); err != nil {
+		errs = append(errs, fmt.Errorf("indexes: %w", err))
+	} else if indexed == 0 {
+		errs = append(errs, errors.New("seats.idx_user_status is missing"))
+	}
+
+	var count int
+	if err := db.QueryRow(`SELECT COUNT(*) FROM seats`).Scan(&count); err != nil {
+		errs = append(errs, fmt.Errorf("count: %w", err))
+	} else if count != cfg.SeatCount {
+		errs = append(errs, fmt.Errorf("seats has %d rows, SEAT_COUNT is %d", count, cfg.SeatCount))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// 좌석 상태
+// DB 에 쓰거나 비교할 때는 문자열 대신 이 타입의 상수를 쓴다
+// 알 수 없는 값은 저장(Value)과 조회(Scan) 모두에서 오류로 처리해 오타가 조용히 들어가지 않게 한다
+type SeatStatus string
+
+const (
+	SeatAvailable SeatStatus = "available"
+	SeatReserved  SeatStatus = "reserved"
+	SeatDisabled  SeatStatus = "disabled" // 판매 중지
+)
+
+func (s SeatStatus) Valid() bool {
+	switch s {
+	case SeatAvailable, SeatReserved, SeatDisabled:
+		return true
+	}
+	return false
+}
+
+// 쿼리 인자로 넘길 때 검증
+func (s SeatStatus) Value() (driver.Value, error) {
+	if !s.Valid() {
+		return nil, fmt.Errorf("invalid seat status %q", string(s))
+	}
+	return string(s), nil
+}
+
+// DB 값 읽을 때 검증
+func (s *SeatStatus) Scan(src any) error {
+	var v string
+	switch src := src.(type) {
+	case string:
+		v = src
+	case []byte:
+		v = string(src)
+	default:
+		return fmt.Errorf("unsupported seat status type %T", src)
+	}
+	if !SeatStatus(v).Valid() {
+		return fmt.Errorf("invalid seat status %q", v)
+	}
+	*s = SeatStatus(v)
+	return nil
+}
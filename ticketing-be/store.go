@@ -0,0 +1,57 @@
+package main
+
+import "time"
+
+// 핵심 좌석 연산 저장소
+// /seats/available, /reserve (nonce 재요청 응답 포함), /reserve/operator, /reserve/cancel, /reserve/cancel-all,
+// /seats/count, /admin/reservations 와 gRPC 서비스가 이 인터페이스만 거쳐 DB 에 접근한다
+// 좌석 여러 개를 잠근 채 상한까지 확인하는 예매 (batch, any, contiguous, preferred, pay) 와
+// 나머지 관리자 API 는 트랜잭션을 핸들러에서 직접 다루므로 db 를 그대로 쓴다
+type SeatStore interface {
+	AvailableSeats() ([]int, error)
+	AvailableSeatsInRange(from, to int) ([]int, error)
+	Reserve(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error)
+	StoredReservation(seatID int) (string, time.Time) // 응답용 확인 코드와 예매 시각
+	Cancel(userID, seatID int) (reserveOutcome, error)
+	CancelAll(userID int) ([]int, reserveOutcome, error)
+	Counts() (map[string]int, error)
+	Reservations(limit, offset int) ([]Reservation, error)
+}
+
+// 현재 사용하는 저장소 (테스트에서 교체 가능)
+var store SeatStore = mysqlStore{}
+
+// MySQL 구현 (db, readDB 사용)
+type mysqlStore struct{}
+
+func (mysqlStore) AvailableSeats() ([]int, error) {
+	return queryAvailableSeats()
+}
+
+func (mysqlStore) AvailableSeatsInRange(from, to int) ([]int, error) {
+	return listAvailableSeatsInRange(from, to)
+}
+
+func (mysqlStore) Reserve(userID, seatID int, nonce string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	return reserveSeat(userID, seatID, nonce, operatorID, code, reservedAt)
+}
+
+func (mysqlStore) StoredReservation(seatID int) (string, time.Time) {
+	return storedReservation(seatID)
+}
+
+func (mysqlStore) Cancel(userID, seatID int) (reserveOutcome, error) {
+	return cancelSeat(userID, seatID)
+}
+
+func (mysqlStore) CancelAll(userID int) ([]int, reserveOutcome, error) {
+	return cancelAllSeats(userID)
+}
+
+func (mysqlStore) Counts() (map[string]int, error) {
+	return querySeatCounts()
+}
+
+func (mysqlStore) Reservations(limit, offset int) ([]Reservation, error) {
+	return queryReservations(limit, offset)
+}
@@ -0,0 +1,298 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MySQL 없이 핸들러를 돌려 보기 위한 메모리 저장소
+type memStore struct {
+	mu    sync.Mutex
+	owner map[int]int // seat_id → user_id (0 이면 빈 좌석)
+	held  map[int]memReservation
+	seq   int64
+}
+
+type memReservation struct {
+	Reservation
+	code string
+}
+
+func newMemStore(seats int) *memStore {
+	s := &memStore{owner: make(map[int]int, seats), held: make(map[int]memReservation)}
+	for id := 1; id <= seats; id++ {
+		s.owner[id] = 0
+	}
+	return s
+}
+
+// 전역 저장소를 좌석 seats 개짜리 memStore 로 바꾸고 설정, 차단기, 캐시를 초기화 (끝나면 원래대로)
+func useMemStore(tb testing.TB, seats int) *memStore {
+	tb.Helper()
+	log.SetOutput(io.Discard)
+	saved := cfg
+	cfg = Config{}
+	reserveBreaker = newCircuitBreaker("reserve", 0, 0)
+	invalidateSeatCache()
+	mem := newMemStore(seats)
+	store = mem
+	tb.Cleanup(func() {
+		cfg = saved
+		store = mysqlStore{}
+		invalidateSeatCache()
+	})
+	return mem
+}
+
+// 모든 좌석을 빈 좌석으로 되돌림 (벤치마크 라운드 사이)
+func (s *memStore) reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id := range s.owner {
+		s.owner[id] = 0
+	}
+	clear(s.held)
+}
+
+func (s *memStore) AvailableSeats() ([]int, error) {
+	return s.AvailableSeatsInRange(1, len(s.owner))
+}
+
+func (s *memStore) AvailableSeatsInRange(from, to int) ([]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var seats []int
+	for id := from; id <= to; id++ {
+		if owner, ok := s.owner[id]; ok && owner == 0 {
+			seats = append(seats, id)
+		}
+	}
+	return seats, nil
+}
+
+func (s *memStore) Reserve(userID, seatID int, _ string, operatorID int, code string, reservedAt time.Time) (reserveOutcome, int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owner, ok := s.owner[seatID]
+	switch {
+	case !ok:
+		return reserveNotFound, 0, nil
+	case owner != 0:
+		return reserveConflict, 0, nil
+	}
+	s.owner[seatID] = userID
+	s.seq++
+	s.held[seatID] = memReservation{Reservation{SeatID: seatID, UserID: userID, ReservedAt: reservedAt, Seq: s.seq, OperatorID: operatorID}, code}
+	return reserveOK, s.seq, nil
+}
+
+func (s *memStore) StoredReservation(seatID int) (string, time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := s.held[seatID]
+	return formatConfirmationCode(res.code), res.ReservedAt
+}
+
+func (s *memStore) Cancel(userID, seatID int) (reserveOutcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	owner, ok := s.owner[seatID]
+	switch {
+	case !ok:
+		return reserveNotFound, nil
+	case owner != userID:
+		return reserveNotOwned, nil
+	}
+	s.owner[seatID] = 0
+	delete(s.held, seatID)
+	return reserveOK, nil
+}
+
+func (s *memStore) CancelAll(userID int) ([]int, reserveOutcome, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	freed := make([]int, 0)
+	for id, owner := range s.owner {
+		if owner == userID {
+			s.owner[id] = 0
+			delete(s.held, id)
+			freed = append(freed, id)
+		}
+	}
+	slices.Sort(freed)
+	return freed, reserveOK, nil
+}
+
+func (s *memStore) Reservations(limit, offset int) ([]Reservation, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	reservations := make([]Reservation, 0)
+	for id := 1; id <= len(s.owner); id++ {
+		if res, ok := s.held[id]; ok {
+			reservations = append(reservations, res.Reservation)
+		}
+	}
+	reservations = reservations[min(offset, len(reservations)):]
+	return reservations[:min(limit, len(reservations))], nil
+}
+
+func (s *memStore) Counts() (map[string]int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	counts := map[string]int{"total": len(s.owner), string(SeatAvailable): 0, string(SeatReserved): 0, string(SeatDisabled): 0}
+	for _, owner := range s.owner {
+		if owner == 0 {
+			counts[string(SeatAvailable)]++
+		} else {
+			counts[string(SeatReserved)]++
+		}
+	}
+	return counts, nil
+}
+
+// /reserve 성공 응답에 저장된 예매 시각이 함께 오는지 확인
+func TestReserveResponseReservedAt(t *testing.T) {
+	useMemStore(t, 10)
+
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	before := time.Now().Truncate(time.Second)
+	body, _ := json.Marshal(TicketRequest{UserID: 1, SeatID: 3})
+	resp, err := http.Post(srv.URL+"/reserve", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var got struct {
+		ReservedAt time.Time `json:"reserved_at"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ReservedAt.Before(before) || got.ReservedAt.After(time.Now()) {
+		t.Fatalf("reserved_at = %v, want between %v and now", got.ReservedAt, before)
+	}
+}
+
+// JWT_SECRET 이 있으면 /reserve 외의 사용자 엔드포인트 (/reserve/cancel) 도 토큰의 sub 를 확인하는지
+func TestCancelRequiresMatchingToken(t *testing.T) {
+	mem := useMemStore(t, 10)
+	cfg.JWTSecret = "secret"
+
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	const seatID = 3
+	steps := []struct {
+		name      string
+		path      string
+		token     string
+		wantCode  int
+		wantOwner int
+	}{
+		{"reserve", "/reserve", signTestJWT(t, "secret", 1), http.StatusOK, 1},
+		{"cancel without token", "/reserve/cancel", "", http.StatusUnauthorized, 1},
+		{"cancel with bad signature", "/reserve/cancel", signTestJWT(t, "other", 1), http.StatusUnauthorized, 1},
+		{"cancel as other user", "/reserve/cancel", signTestJWT(t, "secret", 2), http.StatusForbidden, 1},
+		{"cancel", "/reserve/cancel", signTestJWT(t, "secret", 1), http.StatusOK, 0},
+	}
+	for _, step := range steps {
+		body, _ := json.Marshal(TicketRequest{UserID: 1, SeatID: seatID})
+		req, _ := http.NewRequest(http.MethodPost, srv.URL+step.path, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if step.token != "" {
+			req.Header.Set("Authorization", "Bearer "+step.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != step.wantCode {
+			t.Fatalf("%s: status %d, want %d", step.name, resp.StatusCode, step.wantCode)
+		}
+		if got := mem.owner[seatID]; got != step.wantOwner {
+			t.Fatalf("%s: seat %d owned by %d, want %d", step.name, seatID, got, step.wantOwner)
+		}
+	}
+}
+
+// sub 가 userID 인 HS256 토큰
+func signTestJWT(t *testing.T, secret string, userID int) string {
+	t.Helper()
+	header, _ := json.Marshal(jwtHeader{Alg: "HS256"})
+	claims, _ := json.Marshal(jwtClaims{Sub: strconv.Itoa(userID)})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unsigned))
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// /reserve/cancel-all 과 /admin/reservations 도 SeatStore 를 거치는지 memStore 로 확인
+func TestCancelAllAndAdminListMemStore(t *testing.T) {
+	mem := useMemStore(t, 10)
+	cfg.AdminToken = "admin"
+
+	srv := httptest.NewServer(newMux())
+	defer srv.Close()
+
+	for _, r := range []TicketRequest{{UserID: 1, SeatID: 2}, {UserID: 1, SeatID: 5}, {UserID: 2, SeatID: 7}} {
+		if code := postJSON(t, srv.URL+"/reserve", r); code != http.StatusOK {
+			t.Fatalf("reserve %+v: status %d", r, code)
+		}
+	}
+
+	adminList := func() []Reservation {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/admin/reservations", nil)
+		req.Header.Set("X-Admin-Token", "admin")
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer resp.Body.Close()
+		var list []Reservation
+		if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+			t.Fatal(err)
+		}
+		return list
+	}
+	if list := adminList(); len(list) != 3 || list[0].SeatID != 2 || list[0].ReservationID == nil {
+		t.Fatalf("admin list = %+v, want 3 reservations starting at seat 2 with reservation_id", list)
+	}
+
+	body, _ := json.Marshal(CancelAllRequest{UserID: 1})
+	resp, err := http.Post(srv.URL+"/reserve/cancel-all", "application/json", bytes.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	var got struct {
+		SeatIDs []int `json:"seat_ids"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(got.SeatIDs, []int{2, 5}) {
+		t.Fatalf("cancel-all freed %v, want [2 5]", got.SeatIDs)
+	}
+	if mem.owner[2] != 0 || mem.owner[5] != 0 || mem.owner[7] != 2 {
+		t.Fatalf("owners after cancel-all: %v", mem.owner)
+	}
+	if list := adminList(); len(list) != 1 || list[0].SeatID != 7 {
+		t.Fatalf("admin list after cancel-all = %+v, want only seat 7", list)
+	}
+}
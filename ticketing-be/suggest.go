@@ -0,0 +1,51 @@
+package main
+
+import (
+	"cmp"
+	"net/http"
+	"slices"
+)
+
+// 충돌 응답에 넣을 대체 좌석 수 (?suggest=true)
+const suggestCount = 3
+
+// seatID 와 가까운 빈 좌석 n 개
+// 좌석 배치가 설정돼 있으면 같은 행을 먼저, 그다음 seat_id 차이가 작은 순 (같으면 작은 ID)
+func nearbyAvailableSeats(seatID, n int) ([]int, error) {
+	seats, err := listAvailableSeats()
+	if err != nil {
+		return nil, err
+	}
+	row, _, _, laidOut := seatPosition(seatID)
+	otherRow := func(id int) int {
+		if r, _, _, _ := seatPosition(id); laidOut && r != row {
+			return 1
+		}
+		return 0
+	}
+	dist := func(id int) int { return max(id-seatID, seatID-id) }
+
+	near := slices.Clone(seats) // 캐시된 원본은 건드리지 않음
+	slices.SortFunc(near, func(a, b int) int {
+		return cmp.Or(cmp.Compare(otherRow(a), otherRow(b)), cmp.Compare(dist(a), dist(b)), cmp.Compare(a, b))
+	})
+	return near[:min(n, len(near))], nil
+}
+
+// 이미 예매된 좌석 대신 고를 만한 근처 빈 좌석을 담은 409 응답
+// 조회에 실패하면 제안 없이 보낸다
+func writeConflictWithSuggestions(w http.ResponseWriter, r *http.Request, action string, userID, seatID int) {
+	suggestions, err := nearbyAvailableSeats(seatID, suggestCount)
+	if err != nil {
+		logJSON("WARN", action, userID, seatID, "suggest_fail", err)
+	}
+	if suggestions == nil {
+		suggestions = []int{}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusConflict)
+	encodeJSON(w, action, userID, seatID, map[string]any{
+		"error":       localize(r, "Seat already reserved"),
+		"suggestions": suggestions,
+	})
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// 사용자가 예매한 좌석 한 개
+type UserSeat struct {
+	SeatID     int       `json:"seat_id"`
+	Label      string    `json:"label,omitempty"`
+	Section    string    `json:"section"`
+	Price      int       `json:"price"`
+	ReservedAt time.Time `json:"reserved_at"`
+}
+
+type UserReservations struct {
+	UserID     int        `json:"user_id"`
+	Seats      []UserSeat `json:"seats"`
+	TotalPrice int        `json:"total_price"`
+}
+
+// 사용자의 예매 좌석 목록과 가격 합계 (?user_id=N)
+func userReservationsHandler(w http.ResponseWriter, r *http.Request) {
+	userID, err := strconv.Atoi(r.URL.Query().Get("user_id"))
+	if err != nil || userID <= 0 {
+		http.Error(w, "Invalid user_id", http.StatusBadRequest)
+		logJSON("WARN", "user_reservations", 0, 0, "bad_user_id", nil)
+		return
+	}
+	if !authorizeUser(w, r, "user_reservations", userID, 0) {
+		return
+	}
+
+	resp := UserReservations{UserID: userID, Seats: []UserSeat{}}
+	rows, err := readDB.Query(`SELECT seat_id, COALESCE(label, ''), section, price, reserved_at FROM seats WHERE user_id = ? AND status = ? ORDER BY seat_id`, userID, SeatReserved)
+	if err != nil {
+		logJSON("ERROR", "user_reservations", userID, 0, "query_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+	for rows.Next() {
+		var s UserSeat
+		if err := rows.Scan(&s.SeatID, &s.Label, &s.Section, &s.Price, &s.ReservedAt); err == nil {
+			resp.Seats = append(resp.Seats, s)
+		}
+	}
+	rows.Close()
+
+	err = readDB.QueryRow(`SELECT COALESCE(SUM(price), 0) FROM seats WHERE user_id = ? AND status = ?`, userID, SeatReserved).Scan(&resp.TotalPrice)
+	if err != nil {
+		logJSON("ERROR", "user_reservations", userID, 0, "sum_fail", err)
+		http.Error(w, "internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	logJSON("INFO", "user_reservations", userID, 0, fmt.Sprintf("count=%d total=%d", len(resp.Seats), resp.TotalPrice), nil)
+	w.Header().Set("Content-Type", "application/json")
+	encodeJSON(w, "user_reservations", userID, 0, resp)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// 예매 성공 시 웹훅으로 보내는 내용
+type ReservationEvent struct {
+	UserID     int       `json:"user_id"`
+	SeatID     int       `json:"seat_id"`
+	ReservedAt time.Time `json:"reserved_at"`
+}
+
+// WEBHOOK_URL 이 없으면 nil 로 남아 전송하지 않는다
+var webhookQueue chan ReservationEvent
+
+// 웹훅 전송 워커 시작
+func startWebhook(url string, queueSize, workers int, timeout time.Duration) {
+	if url == "" {
+		return
+	}
+	webhookQueue = make(chan ReservationEvent, queueSize)
+	client := &http.Client{Timeout: timeout}
+	for i := 0; i < workers; i++ {
+		go func() {
+			for ev := range webhookQueue {
+				postWebhook(client, url, ev)
+			}
+		}()
+	}
+}
+
+func postWebhook(client *http.Client, url string, ev ReservationEvent) {
+	body, _ := json.Marshal(ev)
+	resp, err := client.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		logJSON("WARN", "webhook", ev.UserID, ev.SeatID, "post_fail", err)
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		logJSON("WARN", "webhook", ev.UserID, ev.SeatID, "post_fail", fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+}
+
+// 예매 이벤트 전달 (큐가 가득 차면 버린다, 예매 응답을 막지 않음)
+func sendWebhook(userID, seatID int, reservedAt time.Time) {
+	if webhookQueue == nil {
+		return
+	}
+	select {
+	case webhookQueue <- ReservationEvent{UserID: userID, SeatID: seatID, ReservedAt: reservedAt}:
+	default:
+		logJSON("WARN", "webhook", userID, seatID, "queue_full", nil)
+	}
+}
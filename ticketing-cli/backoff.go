@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+)
+
+// 예매 실패 후 다음 시도까지 기다리는 방식 (-backoff)
+const (
+	backoffRandom      = "random"      // 0 ~ Base 사이 무작위 (기존 동작)
+	backoffFixed       = "fixed"       // 항상 Base
+	backoffExponential = "exponential" // Base 부터 연속 실패마다 두 배, Max 까지
+	backoffNone        = "none"        // 기다리지 않음
+)
+
+type backoffPolicy struct {
+	Strategy string
+	Base     time.Duration
+	Max      time.Duration
+}
+
+// 클라이언트가 공유하는 백오프 설정
+var backoff = backoffPolicy{Strategy: backoffRandom, Base: 100 * time.Millisecond, Max: 2 * time.Second}
+
+func (p backoffPolicy) validate() error {
+	switch p.Strategy {
+	case backoffRandom, backoffFixed, backoffExponential, backoffNone:
+	default:
+		return fmt.Errorf("unknown strategy %q", p.Strategy)
+	}
+	if p.Base < 0 || p.Max < p.Base {
+		return fmt.Errorf("need 0 <= backoff-base <= backoff-max, got %v and %v", p.Base, p.Max)
+	}
+	return nil
+}
+
+// failures 번 연속 실패한 뒤의 대기 시간
+func (p backoffPolicy) delay(rng *rand.Rand, failures int) time.Duration {
+	switch p.Strategy {
+	case backoffFixed:
+		return p.Base
+	case backoffExponential:
+		d := p.Base
+		for i := 1; i < failures && d < p.Max; i++ {
+			d *= 2
+		}
+		return min(d, p.Max)
+	case backoffNone:
+		return 0
+	}
+	if p.Base <= 0 {
+		return 0
+	}
+	return time.Duration(rng.Int64N(int64(p.Base)))
+}
@@ -0,0 +1,140 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const usage = `usage: client [command] [flags]
+
+commands:
+  loadtest  run the load test (default when no command is given)
+  reserve   reserve a single seat
+  pipe      reserve each user_id,seat_id line read from stdin
+  status    print remaining seats, total and by section
+  reset     cancel every reservation on the server (admin)
+
+Run "client <command> -h" for the flags of each command.
+`
+
+func main() {
+	cmd, args := "loadtest", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		cmd, args = args[0], args[1:]
+	}
+
+	switch cmd {
+	case "loadtest":
+		runLoadTest(args)
+	case "reserve":
+		runReserve(args)
+	case "pipe":
+		runPipe(args)
+	case "status":
+		runStatus(args)
+	case "reset":
+		runReset(args)
+	case "help":
+		fmt.Print(usage)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n\n%s", cmd, usage)
+		os.Exit(2)
+	}
+}
+
+// 좌석 한 개 예매
+func runReserve(args []string) {
+	fs := flag.NewFlagSet("reserve", flag.ExitOnError)
+	userID := fs.Int("user", 0, "user ID")
+	seatID := fs.Int("seat", 0, "seat ID")
+	fs.Parse(args)
+	if *userID <= 0 || *seatID <= 0 {
+		fmt.Fprintln(os.Stderr, "reserve: -user and -seat are required")
+		os.Exit(2)
+	}
+
+	body, _ := json.Marshal(ReserveRequest{UserID: *userID, SeatID: *seatID})
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post(reserveURL, "application/json", bytes.NewBuffer(body))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reserve: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%d %s\n", resp.StatusCode, strings.TrimSpace(string(msg)))
+	if resp.StatusCode != http.StatusOK {
+		os.Exit(1)
+	}
+}
+
+// 남은 좌석 수와 구역별 현황 출력
+func runStatus(args []string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	fs.Parse(args)
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	count, err := fetchAvailableCount(context.Background(), client)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "status: %v\n", err)
+		os.Exit(1)
+	}
+	fmt.Printf("Available seats: %d\n", count)
+
+	resp, err := client.Get(sectionCountsURL)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "status: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	var sections map[string]map[string]int
+	if err := json.NewDecoder(resp.Body).Decode(&sections); err != nil {
+		fmt.Fprintf(os.Stderr, "status: %v\n", err)
+		os.Exit(1)
+	}
+	for name, counts := range sections {
+		fmt.Printf("  %-12s available=%d reserved=%d\n", name, counts["available"], counts["reserved"])
+	}
+}
+
+// 서버의 모든 예매 취소 (관리자 토큰 필요)
+func runReset(args []string) {
+	fs := flag.NewFlagSet("reset", flag.ExitOnError)
+	adminToken := fs.String("admin-token", "", "admin token (X-Admin-Token)")
+	fs.Parse(args)
+	if *adminToken == "" {
+		fmt.Fprintln(os.Stderr, "reset: -admin-token is required")
+		os.Exit(2)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, resetURL, nil)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reset: %v\n", err)
+		os.Exit(1)
+	}
+	req.Header.Set("X-Admin-Token", *adminToken)
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "reset: %v\n", err)
+		os.Exit(1)
+	}
+	defer resp.Body.Close()
+
+	msg, _ := io.ReadAll(resp.Body)
+	fmt.Printf("%d %s\n", resp.StatusCode, strings.TrimSpace(string(msg)))
+	if resp.StatusCode != http.StatusOK {
+		os.Exit(1)
+	}
+}
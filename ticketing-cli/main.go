@@ -2,13 +2,23 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	// "log"
+	"log"
 	"math/rand/v2"
+	"net"
 	"net/http"
-	// "os"
+	"net/http/httptrace"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
 	"sync"
+	"sync/atomic"
+	"syscall"
 	"time"
 )
 
@@ -20,24 +30,144 @@ type ReserveRequest struct {
 }
 
 type Result struct {
+	UserID     int
+	SeatID     int
 	StatusCode int
 	Duration   time.Duration
 	Err        error
+	At         time.Time     // 응답 수신 시각
+	RetryAfter time.Duration // 서버가 준 Retry-After 힌트 (없으면 0)
+	Verified   bool          // 응답은 못 받았지만 좌석 조회로 성공을 확인함 (Duration 은 시간 초과까지라 RTT 통계에서 제외)
+	DialFailed bool          // 연결을 얻기 전에 실패함 (Err 가 있을 때만 의미 있음)
 }
 
 const (
-	concurrentClients = 5000
-	loadURL           = "http://server:8080/seats/available"
-	reserveURL        = "http://server:8080/reserve"
+	defaultClients   = 5000
+	maxClients       = 50000 // 이보다 많으면 -force 필요
+	loadURL          = "http://server:8080/seats/available"
+	reserveURL       = "http://server:8080/reserve"
+	adminListURL     = "http://server:8080/admin/reservations"
+	adminSeatURL     = "http://server:8080/admin/seat"
+	seatCountURL     = "http://server:8080/seats/count"
+	sectionCountsURL = "http://server:8080/seats/count/by-section"
+	resetURL         = "http://server:8080/admin/seats/reset"
+	cancelURL        = "http://server:8080/reserve/cancel"
 )
 
-func fetchAvailableSeats(client *http.Client) (SeatList, error) {
-	resp, err := client.Get(loadURL)
+// 연결 거부 (서버가 잠깐 accept 를 못 따라가는 경우) 는 짧게 기다렸다 다시 시도한다
+const (
+	refusedRetries = 4
+	refusedDelay   = 20 * time.Millisecond // 재시도마다 두 배
+)
+
+// 본문 없는 조회 요청 전송, 연결 거부면 refusedRetries 번까지 빠르게 재시도
+func doRetryRefused(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		limiter.wait(ctx)
+		resp, err := client.Do(req)
+		if err == nil || !errors.Is(err, syscall.ECONNREFUSED) || attempt >= refusedRetries || ctx.Err() != nil {
+			return resp, err
+		}
+		netFailures.refusedRetried.Add(1)
+		sleepCtx(ctx, refusedDelay<<attempt)
+	}
+}
+
+// 응답을 못 받은 조회가 연속 이만큼 실패하면 서버가 내려간 것으로 보고 클라이언트를 멈춘다
+const maxFetchFailures = 5
+
+// 조회 실패 후 클라이언트를 멈춰야 하는지 (failures 는 이번 실패까지 센 연속 조회 실패 횟수)
+// 연결 끊김, EOF, 본문이 잘려 JSON 디코드에 실패한 응답처럼 목록을 못 받은 오류는 일시적일 수 있어
+// maxFetchFailures 번 연속일 때만 멈춘다
+// 시간 초과와 HTTP 오류 응답은 서버가 바쁜 것이므로 계속 시도
+func fetchFailed(ctx context.Context, err error, failures int) bool {
+	if ctx.Err() != nil {
+		return true
+	}
+	var serr *fetchStatusError
+	var nerr net.Error
+	if errors.As(err, &serr) || (errors.As(err, &nerr) && nerr.Timeout()) {
+		return false
+	}
+	if failures >= maxFetchFailures {
+		netFailures.fetchAborted.Add(1)
+		return true
+	}
+	return false
+}
+
+// 조회가 200 이 아닌 응답을 받음 (429, 503 등)
+type fetchStatusError struct {
+	StatusCode int
+	RetryAfter time.Duration // 서버가 보낸 Retry-After (없으면 0)
+}
+
+func (e *fetchStatusError) Error() string {
+	return fmt.Sprintf("unexpected status %d", e.StatusCode)
+}
+
+func newFetchStatusError(resp *http.Response) *fetchStatusError {
+	return &fetchStatusError{StatusCode: resp.StatusCode, RetryAfter: parseRetryAfter(resp.Header)}
+}
+
+// 초 단위 Retry-After 헤더 (없거나 잘못되면 0)
+func parseRetryAfter(h http.Header) time.Duration {
+	if sec, err := strconv.Atoi(h.Get("Retry-After")); err == nil && sec > 0 {
+		return time.Duration(sec) * time.Second
+	}
+	return 0
+}
+
+// 조회 실패 뒤 다시 조회하기 전 대기
+// 서버 힌트 (Retry-After) 가 있으면 따르고, 없으면 -backoff 전략대로 기다린다
+// -backoff none 이어도 바쁜 서버에 조회를 몰아붙이지 않도록 최소 refusedDelay 는 쉰다
+func waitFetchRetry(ctx context.Context, rng *rand.Rand, err error, failures int) {
+	var serr *fetchStatusError
+	if errors.As(err, &serr) && serr.RetryAfter > 0 {
+		sleepCtx(ctx, serr.RetryAfter)
+		return
+	}
+	sleepCtx(ctx, max(backoff.delay(rng, failures), refusedDelay))
+}
+
+// 빈 좌석 목록을 한 번에 받을 개수 (0 이면 전체, -fetch-size)
+// 좌석이 많으면 전체 목록 전송이 클라이언트 루프의 대부분을 차지해 서버의 ?sample=N 으로 일부만 받는다
+var fetchSize int
+
+// 클라이언트가 경쟁할 구역 (비어 있으면 전체, -section)
+// 서버의 ?section= 으로 거르므로 매진 판단도 이 구역 기준이다
+var seatSection string
+
+// 빈 좌석 조회 URL (sample 이 0 이면 전체 목록)
+func availableSeatsURL(sample int) string {
+	q := url.Values{}
+	if sample > 0 {
+		q.Set("sample", strconv.Itoa(sample))
+	}
+	if seatSection != "" {
+		q.Set("section", seatSection)
+	}
+	if len(q) == 0 {
+		return loadURL
+	}
+	return loadURL + "?" + q.Encode()
+}
+
+func fetchAvailableSeats(ctx context.Context, client *http.Client) (SeatList, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, availableSeatsURL(fetchSize), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := doRetryRefused(ctx, client, req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode != http.StatusOK {
+		return nil, newFetchStatusError(resp)
+	}
+
 	var seats SeatList
 	if err := json.NewDecoder(resp.Body).Decode(&seats); err != nil {
 		return nil, err
@@ -46,45 +176,185 @@ func fetchAvailableSeats(client *http.Client) (SeatList, error) {
 	return seats, nil
 }
 
-func tryReserve(client *http.Client, req ReserveRequest) Result {
+// 남은 좌석 수만 조회 (HEAD 요청, 목록 본문 없이 X-Available-Count 헤더만 받음)
+func fetchAvailableCount(ctx context.Context, client *http.Client) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, availableSeatsURL(0), nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := doRetryRefused(ctx, client, req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return 0, newFetchStatusError(resp)
+	}
+	return strconv.Atoi(resp.Header.Get("X-Available-Count"))
+}
+
+// 예매 요청 한 번의 응답 대기 한도 (0 이면 없음, -attempt-timeout)
+// http.Client.Timeout 은 연결 수립까지 포함한 전체 한도라 따로 둔다
+var attemptTimeout time.Duration
+
+// 응답을 받지 못한 요청의 원인별 건수
+var netFailures struct {
+	attemptDeadline atomic.Int64 // -attempt-timeout 초과
+	clientTimeout   atomic.Int64 // -client-timeout 초과 (연결 수립 지연 포함)
+	other           atomic.Int64
+	verified        atomic.Int64 // 시간 초과였지만 서버에는 예매가 반영된 건
+
+	// 위와 별개로 실패 시점별 건수
+	dial      atomic.Int64 // 연결을 얻기 전에 실패 (dial, DNS, 연결 거부)
+	midFlight atomic.Int64 // 연결을 얻은 뒤 요청 쓰기나 응답 읽기 중 실패
+
+	// 좌석 조회 (fetchAvailableCount, fetchAvailableSeats)
+	refusedRetried atomic.Int64 // 연결 거부로 빠르게 재시도한 횟수
+	fetchAborted   atomic.Int64 // 연속 maxFetchFailures 번 응답이 없어 멈춘 클라이언트 수
+}
+
+// 서버가 410 으로 전체 예매 상한 (MAX_TOTAL_RESERVATIONS) 도달을 알렸는지
+// 좌석이 남아 있어도 더 시도하지 않고, 재고 검사에서 매진을 기대하지 않는다
+var capReached atomic.Bool
+
+// 시간 초과 후 좌석 상태로 실제 결과를 확인할 때 쓰는 관리자 토큰 (-verify-timeouts, 비어 있으면 확인 안 함)
+var verifyToken string
+
+// 원인별로 세고, 시간 초과였는지 돌려준다
+func countNetFailure(ctx context.Context, err error) bool {
+	var netErr net.Error
+	switch {
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		netFailures.attemptDeadline.Add(1)
+		return true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		netFailures.clientTimeout.Add(1)
+		return true
+	default:
+		netFailures.other.Add(1)
+		return false
+	}
+}
+
+// runCtx 가 끝나면 (-max-runtime) 진행 중인 요청도 취소된다
+func tryReserve(runCtx context.Context, client *http.Client, req ReserveRequest) Result {
+	ctx := runCtx
+	if attemptTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, attemptTimeout)
+		defer cancel()
+	}
+
 	body, _ := json.Marshal(req)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, reserveURL, bytes.NewBuffer(body))
+	if err != nil {
+		return Result{UserID: req.UserID, SeatID: req.SeatID, StatusCode: 0, Err: err}
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	// 연결을 얻었는지 기록해 연결 수립 실패와 요청 도중 실패를 구분
+	var connected atomic.Bool
+	httpReq = httpReq.WithContext(httptrace.WithClientTrace(httpReq.Context(), &httptrace.ClientTrace{
+		GotConn: func(httptrace.GotConnInfo) { connected.Store(true) },
+	}))
+
+	// -rate 대기는 응답 시간에 넣지 않는다
+	limiter.wait(ctx)
 	start := time.Now()
-	resp, err := client.Post(reserveURL, "application/json", bytes.NewBuffer(body))
+	resp, err := client.Do(httpReq)
 	duration := time.Since(start)
 
 	if err != nil {
-		return Result{StatusCode: 0, Duration: duration, Err: err}
+		result := Result{UserID: req.UserID, SeatID: req.SeatID, StatusCode: 0, Duration: duration, Err: err, DialFailed: !connected.Load()}
+		if runCtx.Err() != nil {
+			return result // 실행 시간 초과로 취소됨, 원인 집계 제외
+		}
+		if result.DialFailed {
+			netFailures.dial.Add(1)
+		} else {
+			netFailures.midFlight.Add(1)
+		}
+		// 응답만 유실되고 서버에서는 예매가 끝났을 수 있으므로 좌석 주인을 확인해 실제 결과로 기록
+		if countNetFailure(ctx, err) && verifyToken != "" {
+			if owner, verr := fetchSeatOwner(client, verifyToken, req.SeatID); verr == nil && owner == req.UserID {
+				netFailures.verified.Add(1)
+				result.StatusCode = http.StatusOK
+				result.Err = nil
+				result.Verified = true
+				result.At = start.Add(duration)
+			}
+		}
+		return result
 	}
 	defer resp.Body.Close()
 
-	return Result{StatusCode: resp.StatusCode, Duration: duration}
+	return Result{UserID: req.UserID, SeatID: req.SeatID, StatusCode: resp.StatusCode, Duration: duration, At: start.Add(duration), RetryAfter: parseRetryAfter(resp.Header)}
+}
+
+// 클라이언트별 난수 생성기
+// seed 가 0 이 아니면 seed 와 사용자 ID 로 고정해 같은 실행을 재현할 수 있게 한다
+func newClientRand(seed uint64, userID int) *rand.Rand {
+	if seed == 0 {
+		return rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+	}
+	return rand.New(rand.NewPCG(seed, uint64(userID)))
+}
+
+// ctx 가 끝날 때까지 d 만큼 대기
+func sleepCtx(ctx context.Context, d time.Duration) {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+	case <-t.C:
+	}
 }
 
-func simulateClient(userID int, client *http.Client, wg *sync.WaitGroup, results chan<- []Result) {
+// 매진되거나 (상한 도달 포함) ctx 가 끝날 때까지 (-max-runtime) 예매 시도
+func simulateClient(ctx context.Context, userID int, client *http.Client, rng *rand.Rand, wg *sync.WaitGroup, results chan<- []Result) {
 	defer wg.Done()
 
 	currentResults := make([]Result, 0)
+	lost := make(map[int]bool) // 충돌로 놓친 좌석 (다시 시도하지 않음)
+	failures := 0              // 연속 실패 횟수 (exponential 백오프용)
+	fetchFailures := 0         // 연속 조회 실패 횟수
 
-	for {
-		seats, err := fetchAvailableSeats(client)
+	for ctx.Err() == nil && !capReached.Load() {
+		seats, err := fetchAvailableSeats(ctx, client)
 		if err != nil {
+			fetchFailures++
+			if fetchFailed(ctx, err, fetchFailures) {
+				break
+			}
+			waitFetchRetry(ctx, rng, err, fetchFailures)
 			continue
 		}
+		fetchFailures = 0
 
 		if len(seats) == 0 {
 			break
 		}
 
+		// 이미 놓친 좌석 제외, 남은 좌석이 전부 놓친 좌석이면 (취소로 풀린 경우) 다시 시도 허용
+		untried := slices.DeleteFunc(slices.Clone(seats), func(id int) bool { return lost[id] })
+		if len(untried) > 0 {
+			seats = untried
+		} else {
+			clear(lost)
+		}
+
 		// 좌석 셔플
-		rand.Shuffle(len(seats), func(i, j int) {
+		rng.Shuffle(len(seats), func(i, j int) {
 			seats[i], seats[j] = seats[j], seats[i]
 		})
 
+		conflicted := false // 이번 회차가 충돌로만 끝났는지
 		for i := 0; i < len(seats) && i < 3; i++ {
 			seatID := seats[i]
 
 			// 측정 대상: 딱 한 번의 리퀘스트-리스폰 시간
-			result := tryReserve(client, ReserveRequest{
+			result := tryReserve(ctx, client, ReserveRequest{
 				UserID: userID,
 				SeatID: seatID,
 			})
@@ -97,10 +367,34 @@ func simulateClient(userID int, client *http.Client, wg *sync.WaitGroup, results
 			currentResults = append(currentResults, result)
 
 			if result.StatusCode == http.StatusOK {
+				failures = 0
+				conflicted = false
 				break
 			}
+			failures++
+			if result.StatusCode == http.StatusGone {
+				capReached.Store(true)
+				break
+			}
+			if result.StatusCode == http.StatusConflict {
+				lost[seatID] = true
+				conflicted = true
+			}
 
-			time.Sleep(time.Duration(int(rand.Float64()*100)) * time.Millisecond)
+			// 서버 힌트가 있으면 따르고, 없으면 -backoff 전략대로
+			if result.RetryAfter > 0 {
+				sleepCtx(ctx, result.RetryAfter)
+			} else if d := backoff.delay(rng, failures); d > 0 {
+				sleepCtx(ctx, d)
+			}
+		}
+
+		// 충돌로 끝났으면 매진됐을 수 있으므로 목록 대신 개수만 확인 (HEAD)
+		// 조회 오류는 다음 회차의 목록 조회에서 처리한다
+		if conflicted {
+			if count, err := fetchAvailableCount(ctx, client); err == nil && count == 0 {
+				break
+			}
 		}
 	}
 
@@ -116,93 +410,283 @@ func simulateClient(userID int, client *http.Client, wg *sync.WaitGroup, results
 	results <- currentResults
 }
 
-func main() {
-	var wg sync.WaitGroup
-	results := make(chan []Result, concurrentClients)
-	client := &http.Client{Timeout: 5 * time.Second}
-
-	fmt.Println("Starting load test...")
-	time.Sleep(10 * time.Second) // 서버 안정화 대기
+// 응답을 받지 못한 예매 요청 원인별 집계 출력
+func printNetFailures() {
+	fmt.Printf("Reserve requests without response: attempt deadline %d, client timeout %d, other %d (verified as success: %d)\n",
+		netFailures.attemptDeadline.Load(), netFailures.clientTimeout.Load(), netFailures.other.Load(), netFailures.verified.Load())
+	fmt.Printf("  ↳ by phase: connection not established %d, failed after connecting %d\n",
+		netFailures.dial.Load(), netFailures.midFlight.Load())
+	fmt.Printf("Seat fetches: connection refused retries %d, clients stopped by fetch failures %d\n",
+		netFailures.refusedRetried.Load(), netFailures.fetchAborted.Load())
+}
 
-	for i := 0; i < concurrentClients; i++ {
-		wg.Add(1)
-		go simulateClient(1000+i, client, &wg, results)
+// 클라이언트마다 연결 하나씩 잡는다고 보고 RLIMIT_NOFILE 을 올려 보고, 모자라면 경고
+func checkFileLimit(clients int) {
+	const slack = 64 // 표준 입출력, DNS, 관리자 API 등
+	want := uint64(clients + slack)
+	got, err := raiseFileLimit(want)
+	if err != nil {
+		fmt.Printf("⚠️  Could not check the open file limit (%v); %d clients need about %d descriptors\n", err, clients, want)
+		return
+	}
+	if got < want {
+		fmt.Printf("⚠️  Open file limit is %d but %d clients need about %d descriptors; expect \"too many open files\" (raise ulimit -n)\n", got, clients, want)
 	}
+}
 
-	wg.Wait()
-	close(results)
+// 연결 하나만 재사용하는 HTTP 클라이언트 (keep-alive 직렬화 효과 측정용)
+func newSingleConnClient(timeout time.Duration, cnet clientNet) *http.Client {
+	return cnet.apply(&http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			MaxConnsPerHost:     1,
+			MaxIdleConnsPerHost: 1,
+		},
+	})
+}
 
-	var (
-		successCount    int
-		successTotalRTT time.Duration
+// 프록시와 리다이렉트 설정 (-proxy, -no-redirect)
+type clientNet struct {
+	proxy      *url.URL // nil 이면 환경 변수 (HTTP_PROXY 등) 를 따름
+	noRedirect bool     // 3xx 를 따라가지 않고 그대로 결과로 받음
+}
 
-		failCount    int
-		failTotalRTT time.Duration
+func (n clientNet) apply(c *http.Client) *http.Client {
+	t, ok := c.Transport.(*http.Transport)
+	if !ok {
+		t = http.DefaultTransport.(*http.Transport).Clone()
+		c.Transport = t
+	}
+	t.Proxy = http.ProxyFromEnvironment
+	if n.proxy != nil {
+		t.Proxy = http.ProxyURL(n.proxy)
+	}
+	if n.noRedirect {
+		c.CheckRedirect = func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+	}
+	return c
+}
 
-		requestFailCount int
-	)
-	var allResults []Result
-	for rr := range results {
-		for _, r := range rr {
-			allResults = append(allResults, r)
+// i번째 클라이언트의 사용자 ID
+// userCount 가 0보다 크면 클라이언트들이 userCount 개의 ID를 나눠 쓴다
+func userIDFor(i, userBase, userCount int) int {
+	if userCount > 0 {
+		return userBase + i%userCount
+	}
+	return userBase + i
+}
 
-			if r.Duration == 0 {
-				// 네트워크 실패 (요청 자체가 실패했음)
-				requestFailCount++
-				continue
-			}
+// 부하 테스트 실행 (기본 하위 명령)
+func runLoadTest(args []string) {
+	fs := flag.NewFlagSet("loadtest", flag.ExitOnError)
+	clients := fs.Int("clients", defaultClients, "number of concurrent clients (uniform and soak profiles)")
+	force := fs.Bool("force", false, fmt.Sprintf("allow more than %d concurrent clients", maxClients))
+	userBase := fs.Int("user-base", 1000, "first user ID assigned to clients")
+	userCount := fs.Int("user-count", 0, "number of distinct user IDs shared by clients (0 = one per client)")
+	connPerClient := fs.Bool("conn-per-client", false, "give each client its own HTTP client limited to a single keep-alive connection")
+	expectSuccess := fs.Int("expect-success", 0, "exit non-zero if fewer than this many reservations succeed")
+	adminToken := fs.String("admin-token", "", "admin token used to cross-check reservations with the server after the run")
+	replayPath := fs.String("replay", "", "CSV trace of (timestamp, user_id, seat_id) to replay instead of the synthetic load")
+	profile := fs.String("profile", profileUniform, "client launch profile: uniform, flashsale or soak")
+	waves := fs.Int("waves", 1, "flashsale: number of client waves")
+	waveSize := fs.Int("wave-size", defaultClients, "flashsale: clients released at once in each wave")
+	waveInterval := fs.Duration("wave-interval", 5*time.Second, "flashsale: delay between waves")
+	soakDuration := fs.Duration("soak-duration", 30*time.Minute, "soak: how long clients keep reserving and cancelling")
+	soakHold := fs.Duration("soak-hold", 100*time.Millisecond, "soak: how long a seat is held before it is cancelled")
+	soakReport := fs.Duration("soak-report", 30*time.Second, "soak: interval between progress reports")
+	checkInv := fs.Bool("check-inventory", true, "after the run, verify seat counts add up and (except in replay mode) no seats remain")
+	clientTimeout := fs.Duration("client-timeout", 5*time.Second, "http.Client timeout covering connection setup and response")
+	attemptTO := fs.Duration("attempt-timeout", 0, "per reserve attempt response deadline via request context (0 = none)")
+	verifyTimeouts := fs.Bool("verify-timeouts", false, "after a timed-out reserve, look up the seat owner (needs -admin-token) and count it as a success if it went through")
+	maxRuntime := fs.Duration("max-runtime", 0, "stop all clients after this long (measured after the warmup) even if seats remain (0 = no limit)")
+	fetchSz := fs.Int("fetch-size", 0, "fetch a random sample of this many available seats per loop instead of the full list (0 = full list)")
+	proxy := fs.String("proxy", "", "route all requests through this proxy URL (default: HTTP_PROXY/HTTPS_PROXY environment)")
+	noRedirect := fs.Bool("no-redirect", false, "do not follow redirects; a 3xx response is recorded as the result")
+	backoffStrategy := fs.String("backoff", backoffRandom, "delay before the next attempt after a failed reserve: random, fixed, exponential or none")
+	backoffBase := fs.Duration("backoff-base", 100*time.Millisecond, "random: upper bound; fixed: delay; exponential: first delay")
+	backoffMax := fs.Duration("backoff-max", 2*time.Second, "exponential: upper bound of the delay")
+	rate := fs.Float64("rate", 0, "cap on aggregate requests per second across all clients, seat fetches included (0 = unlimited)")
+	rateBurst := fs.Int("rate-burst", 1, "requests that may be sent back to back when under the -rate cap")
+	section := fs.String("section", "", "compete only for seats in this section (e.g. VIP); sellout then means this section is sold out")
+	maxP99 := fs.Duration("max-p99", 0, "exit non-zero if the p99 reserve RTT exceeds this (0 = no check; not applied to the soak profile)")
+	slowest := fs.Int("slowest", 0, "list the N slowest successful and failed reserve requests in the report (0 = off)")
+	seed := fs.Uint64("seed", 0, "seed for per-client shuffling and backoff jitter (0 = random)")
+	fs.Parse(args)
+
+	flash := FlashSale{Waves: *waves, WaveSize: *waveSize, Interval: *waveInterval}
+	switch *profile {
+	case profileUniform:
+	case profileFlashSale:
+		if flash.Waves <= 0 || flash.WaveSize <= 0 || flash.Interval < 0 {
+			log.Fatalf("flashsale 설정 오류: waves=%d wave-size=%d wave-interval=%v", flash.Waves, flash.WaveSize, flash.Interval)
+		}
+	case profileSoak:
+		if *soakDuration <= 0 || *soakHold < 0 || *soakReport <= 0 {
+			log.Fatalf("soak 설정 오류: soak-duration=%v soak-hold=%v soak-report=%v", *soakDuration, *soakHold, *soakReport)
+		}
+		if *replayPath != "" {
+			log.Fatalf("-replay 와 soak profile 은 함께 쓸 수 없습니다")
+		}
+	default:
+		log.Fatalf("알 수 없는 profile: %q", *profile)
+	}
 
-			if r.StatusCode == http.StatusOK {
-				// 예매 성공
-				successCount++
-				successTotalRTT += r.Duration
-			} else {
-				// 예매 실패 (응답은 옴)
-				failCount++
-				failTotalRTT += r.Duration
-			}
+	// 실수로 큰 값을 넘겨 디스크립터가 바닥나 "too many open files" 로 끝나는 것을 막는다
+	launched := *clients
+	if *profile == profileFlashSale {
+		launched = flash.Clients()
+	}
+	if launched <= 0 {
+		log.Fatalf("clients 는 1 이상이어야 합니다: %d", launched)
+	}
+	if launched > maxClients && !*force {
+		log.Fatalf("동시 클라이언트 %d 개는 상한 %d 을 넘습니다 (정말 필요하면 -force)", launched, maxClients)
+	}
+	checkFileLimit(launched)
+
+	var trace []TraceEntry
+	if *replayPath != "" {
+		var err error
+		trace, err = loadTrace(*replayPath)
+		if err != nil {
+			log.Fatalf("트레이스 읽기 실패: %v", err)
+		}
+	}
+
+	var wg sync.WaitGroup
+	results := make(chan []Result, max(*clients, len(trace), flash.Clients()))
+	cnet := clientNet{noRedirect: *noRedirect}
+	if *proxy != "" {
+		u, err := url.Parse(*proxy)
+		if err != nil || u.Host == "" {
+			log.Fatalf("proxy URL 오류: %q", *proxy)
+		}
+		cnet.proxy = u
+	}
+	client := cnet.apply(&http.Client{Timeout: *clientTimeout})
+	attemptTimeout = *attemptTO
+	backoff = backoffPolicy{Strategy: *backoffStrategy, Base: *backoffBase, Max: *backoffMax}
+	if err := backoff.validate(); err != nil {
+		log.Fatalf("backoff 설정 오류: %v", err)
+	}
+	if *rate < 0 || *rateBurst < 1 {
+		log.Fatalf("rate 설정 오류: rate=%v rate-burst=%d", *rate, *rateBurst)
+	}
+	if *rate > 0 {
+		limiter = newRateLimiter(*rate, *rateBurst)
+	}
+	if *fetchSz < 0 {
+		log.Fatalf("fetch-size 는 0 이상이어야 합니다: %d", *fetchSz)
+	}
+	fetchSize = *fetchSz
+	seatSection = *section
+	if *verifyTimeouts {
+		if *adminToken == "" {
+			log.Fatalf("-verify-timeouts 는 -admin-token 이 필요합니다")
 		}
+		verifyToken = *adminToken
 	}
 
-	// 평균 계산
-	// var (
-	// 	successAvgRTT time.Duration
-	// 	failAvgRTT    time.Duration
-	// )
+	fmt.Println("Starting load test...")
+	if seatSection != "" {
+		fmt.Printf("Clients compete only in section %q\n", seatSection)
+	}
+	if limiter != nil {
+		fmt.Printf("Request rate capped at %v/s (burst %d)\n", *rate, *rateBurst)
+	}
+	time.Sleep(10 * time.Second) // 서버 안정화 대기
 
-	// if successCount > 0 {
-	// 	successAvgRTT = successTotalRTT / time.Duration(successCount)
-	// }
+	// 없는 구역이면 서버가 400 을 돌려 클라이언트가 끝없이 재시도하므로 미리 확인
+	if seatSection != "" {
+		if _, err := fetchAvailableCount(context.Background(), client); err != nil {
+			log.Fatalf("구역 %q 조회 실패: %v", seatSection, err)
+		}
+	}
 
-	// if failCount > 0 {
-	// 	failAvgRTT = failTotalRTT / time.Duration(failCount)
-	// }
+	clientFor := func(int) *http.Client {
+		if *connPerClient {
+			return newSingleConnClient(*clientTimeout, cnet)
+		}
+		return client
+	}
 
-	// result := ""
+	// 서버가 멈춰도 끝나도록 전체 실행 시간 제한
+	// WithTimeout 대신 cancel 을 써서 요청별 -attempt-timeout 초과와 구분한다
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var cutShort atomic.Bool
+	if *maxRuntime > 0 {
+		timer := time.AfterFunc(*maxRuntime, func() {
+			cutShort.Store(true)
+			cancel()
+		})
+		defer timer.Stop()
+	}
 
-	// 출력
-	// fmt.Println("✅ Detailed Load Test Results")
-	// result += "✅ Detailed Load Test Results\n"
-	// fmt.Printf("Request Failures (no HTTP response): %d\n", requestFailCount)
-	// result += fmt.Sprintf("Request Failures (no HTTP response): %d\n", requestFailCount)
+	// soak 은 매진을 목표로 하지 않으므로 좌석 상태 합계만 확인하고 끝낸다
+	if *profile == profileSoak {
+		soak := Soak{Duration: *soakDuration, Hold: *soakHold, Report: *soakReport}
+		ok := runSoak(ctx, soak, *clients, *seed, clientFor, func(i int) int { return userIDFor(i, *userBase, *userCount) })
+		printNetFailures()
+		if (*checkInv && !checkInventory(client, false)) || !ok {
+			os.Exit(1)
+		}
+		return
+	}
 
-	// fmt.Printf("Reservation Success: %d\n", successCount)
-	// result += fmt.Sprintf("Reservation Success: %d\n", successCount)
-	// fmt.Printf("  ↳ Avg RTT: %v\n", successAvgRTT)
-	// result += fmt.Sprintf("  ↳ Avg RTT: %v\n", successAvgRTT)
+	if trace != nil {
+		replayTrace(ctx, trace, client, &wg, results)
+	} else if *profile == profileFlashSale {
+		launchFlashSale(ctx, flash, *seed, clientFor, func(i int) int { return userIDFor(i, *userBase, *userCount) }, &wg, results)
+	} else {
+		for i := 0; i < *clients; i++ {
+			wg.Add(1)
+			userID := userIDFor(i, *userBase, *userCount)
+			go simulateClient(ctx, userID, clientFor(i), newClientRand(*seed, userID), &wg, results)
+		}
+	}
 
-	// fmt.Printf("Reservation Failure: %d\n", failCount)
-	// result += fmt.Sprintf("Reservation Failure: %d\n", failCount)
-	// fmt.Printf("  ↳ Avg RTT: %v\n", failAvgRTT)
-	// result += fmt.Sprintf("  ↳ Avg RTT: %v\n", failAvgRTT)
+	wg.Wait()
+	close(results)
+	if cutShort.Load() {
+		fmt.Printf("⏱ Max runtime %v reached: clients were stopped before sellout\n", *maxRuntime)
+	}
+	if capReached.Load() {
+		fmt.Println("Server reservation cap reached (410 sold_out): clients stopped with seats remaining")
+	}
 
-	// f, err := os.OpenFile("/results/load_test_results.txt", os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0777)
-	// if err != nil {
-	// 	log.Fatalf("파일 열기 실패: %v", err)
-	// }
-	// defer f.Close()
+	var successCount int
+	var allResults []Result
+	for rr := range results {
+		for _, r := range rr {
+			allResults = append(allResults, r)
+			if r.Duration > 0 && r.StatusCode == http.StatusOK {
+				successCount++
+			}
+		}
+	}
 
-	// if _, err := f.WriteString(result + "\n"); err != nil {
-	// 	log.Fatalf("파일 쓰기 실패: %v", err)
-	// }
+	printSellout(allResults)
+	printLatencyHistogram(allResults)
+	printLatencyPercentiles(allResults)
+	printSlowest(allResults, *slowest)
+	checkDuplicateReservations(client, *adminToken, allResults)
+	printNetFailures()
+	inventoryOK := !*checkInv || checkInventory(client, trace == nil && !cutShort.Load() && !capReached.Load() && seatSection == "")
+
+	// 어긴 기준을 모두 출력한 뒤 종료
+	passed := inventoryOK
+	if successCount < *expectSuccess {
+		fmt.Printf("❌ Expected at least %d successful reservations, got %d\n", *expectSuccess, successCount)
+		passed = false
+	}
+	if *maxP99 > 0 {
+		if p99, ok := latencyPercentile(allResults, 99); ok && p99 > *maxP99 {
+			fmt.Printf("❌ p99 RTT %v exceeds -max-p99 %v\n", p99, *maxP99)
+			passed = false
+		}
+	}
+	if !passed {
+		os.Exit(1)
+	}
 }
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// "user_id,seat_id" 한 줄 파싱
+func parsePipeLine(s string) (ReserveRequest, error) {
+	user, seat, ok := strings.Cut(s, ",")
+	if !ok {
+		return ReserveRequest{}, fmt.Errorf("expected user_id,seat_id: %q", s)
+	}
+	userID, err := strconv.Atoi(strings.TrimSpace(user))
+	if err != nil || userID <= 0 {
+		return ReserveRequest{}, fmt.Errorf("invalid user_id %q", user)
+	}
+	seatID, err := strconv.Atoi(strings.TrimSpace(seat))
+	if err != nil || seatID <= 0 {
+		return ReserveRequest{}, fmt.Errorf("invalid seat_id %q", seat)
+	}
+	return ReserveRequest{UserID: userID, SeatID: seatID}, nil
+}
+
+// 입력의 각 줄을 차례로 예매하고 줄마다 결과 출력
+// 출력: 줄 번호, user_id, seat_id, 상태 코드 (응답이 없으면 error), 소요 시간
+// 빈 줄과 # 주석은 건너뛰고, 예매 실패나 잘못된 줄이 있었는지 돌려준다
+func pipeReservations(ctx context.Context, in io.Reader, out io.Writer, client *http.Client) (bool, error) {
+	ok := true
+	sc := bufio.NewScanner(in)
+	for line := 1; sc.Scan(); line++ {
+		text := strings.TrimSpace(sc.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		// 헤더 행 건너뛰기
+		if line == 1 && strings.HasPrefix(strings.ToLower(text), "user_id") {
+			continue
+		}
+
+		req, err := parsePipeLine(text)
+		if err != nil {
+			fmt.Fprintf(out, "%d\t-\t-\tinvalid\t%v\n", line, err)
+			ok = false
+			continue
+		}
+
+		r := tryReserve(ctx, client, req)
+		if r.Err != nil {
+			fmt.Fprintf(out, "%d\t%d\t%d\terror\t%v\n", line, req.UserID, req.SeatID, r.Err)
+			ok = false
+			continue
+		}
+		fmt.Fprintf(out, "%d\t%d\t%d\t%d\t%v\n", line, req.UserID, req.SeatID, r.StatusCode, r.Duration.Round(time.Microsecond))
+		if r.StatusCode != http.StatusOK {
+			ok = false
+		}
+	}
+	return ok, sc.Err()
+}
+
+// 표준 입력의 user_id,seat_id 쌍을 한 줄씩 예매 (스크립트, 파이프라인용)
+func runPipe(args []string) {
+	fs := flag.NewFlagSet("pipe", flag.ExitOnError)
+	timeout := fs.Duration("client-timeout", 5*time.Second, "http.Client timeout for each reservation")
+	fs.Parse(args)
+
+	client := &http.Client{Timeout: *timeout}
+	ok, err := pipeReservations(context.Background(), os.Stdin, os.Stdout, client)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "pipe: %v\n", err)
+		os.Exit(1)
+	}
+	if !ok {
+		os.Exit(1)
+	}
+}
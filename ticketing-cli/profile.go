@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"math/rand/v2"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 부하 시작 방식
+const (
+	profileUniform   = "uniform"   // 클라이언트를 만드는 대로 바로 시작
+	profileFlashSale = "flashsale" // 웨이브마다 클라이언트를 모아 두었다가 한꺼번에 출발
+	profileSoak      = "soak"      // 정해진 시간 동안 예매와 취소를 반복 (자원 누수 확인용)
+)
+
+// 오픈 직후 몰려드는 예매 폭주 재현 설정
+type FlashSale struct {
+	Waves    int           // 웨이브 수
+	WaveSize int           // 웨이브당 클라이언트 수
+	Interval time.Duration // 웨이브 사이 간격
+}
+
+func (f FlashSale) Clients() int {
+	return f.Waves * f.WaveSize
+}
+
+// 웨이브 단위로 클라이언트를 동시에 출발시킨다
+// 고루틴을 미리 띄워 gate 에서 대기시켰다가 close 로 한 번에 풀어 생성 시간만큼 퍼지지 않게 한다
+func launchFlashSale(ctx context.Context, f FlashSale, seed uint64, clientFor func(i int) *http.Client, userIDFor func(i int) int, wg *sync.WaitGroup, results chan<- []Result) {
+	for wave := 0; wave < f.Waves; wave++ {
+		if wave > 0 {
+			sleepCtx(ctx, f.Interval)
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		gate := make(chan struct{})
+		for k := 0; k < f.WaveSize; k++ {
+			i := wave*f.WaveSize + k
+			wg.Add(1)
+			userID := userIDFor(i)
+			go func(client *http.Client, rng *rand.Rand) {
+				<-gate
+				simulateClient(ctx, userID, client, rng, wg, results)
+			}(clientFor(i), newClientRand(seed, userID))
+		}
+		close(gate)
+	}
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// 모든 클라이언트가 공유하는 초당 요청 수 제한 (-rate)
+// 토큰이 다시 차는 시각을 하나로 관리하는 토큰 버킷 (GCRA), burst 개까지는 몰아서 보낼 수 있다
+type rateLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration // 토큰 하나가 차는 시간
+	burst    time.Duration // interval * (burst-1)
+	tat      time.Time     // 버킷이 가득 차는 시각
+}
+
+// 제한이 없으면 nil
+var limiter *rateLimiter
+
+func newRateLimiter(rps float64, burst int) *rateLimiter {
+	interval := time.Duration(float64(time.Second) / rps)
+	return &rateLimiter{interval: interval, burst: interval * time.Duration(burst-1)}
+}
+
+// 토큰을 하나 예약하고 쓸 수 있을 때까지 대기
+// 예약은 되돌리지 않으므로 ctx 가 끝나 요청을 못 보내도 그 몫은 비워 둔다
+func (l *rateLimiter) wait(ctx context.Context) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	now := time.Now()
+	if l.tat.Before(now) {
+		l.tat = now
+	}
+	at := l.tat.Add(-l.burst)
+	l.tat = l.tat.Add(l.interval)
+	l.mu.Unlock()
+
+	if d := at.Sub(now); d > 0 {
+		sleepCtx(ctx, d)
+	}
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 기록된 요청 한 건 (첫 요청 기준 상대 시각)
+type TraceEntry struct {
+	Offset time.Duration
+	Req    ReserveRequest
+}
+
+// timestamp 필드는 RFC3339 시각 또는 초 단위 숫자를 받는다
+func parseTraceTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, s); err == nil {
+		return t, nil
+	}
+	sec, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+	}
+	return time.Unix(0, int64(sec*float64(time.Second))), nil
+}
+
+// (timestamp, user_id, seat_id) CSV 읽기
+func loadTrace(path string) ([]TraceEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := csv.NewReader(f)
+	r.FieldsPerRecord = 3
+	r.TrimLeadingSpace = true
+
+	var (
+		entries []TraceEntry
+		first   time.Time
+	)
+	for line := 1; ; line++ {
+		rec, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		// 헤더 행 건너뛰기
+		if line == 1 && strings.EqualFold(rec[0], "timestamp") {
+			continue
+		}
+
+		ts, err := parseTraceTime(rec[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		userID, err := strconv.Atoi(rec[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid user_id %q", line, rec[1])
+		}
+		seatID, err := strconv.Atoi(rec[2])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid seat_id %q", line, rec[2])
+		}
+
+		if len(entries) == 0 {
+			first = ts
+		}
+		entries = append(entries, TraceEntry{
+			Offset: ts.Sub(first),
+			Req:    ReserveRequest{UserID: userID, SeatID: seatID},
+		})
+	}
+
+	return entries, nil
+}
+
+// 기록된 시각에 맞춰 예매 요청 재현
+// ctx 가 끝나면 (-max-runtime) 남은 요청은 보내지 않는다
+func replayTrace(ctx context.Context, entries []TraceEntry, client *http.Client, wg *sync.WaitGroup, results chan<- []Result) {
+	start := time.Now()
+	for _, e := range entries {
+		sleepCtx(ctx, time.Until(start.Add(e.Offset)))
+		if ctx.Err() != nil {
+			return
+		}
+
+		wg.Add(1)
+		go func(req ReserveRequest) {
+			defer wg.Done()
+			result := tryReserve(ctx, client, req)
+			if result.Err != nil {
+				// 네트워크 오류는 요청 실패로 집계
+				result.Duration = 0
+			}
+			results <- []Result{result}
+		}(e.Req)
+	}
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// 첫 예매 성공부터 마지막 좌석 예매까지의 시간과 초당 예매 수 타임라인 출력
+func printSellout(results []Result) {
+	var successTimes []time.Time
+	for _, r := range results {
+		if r.StatusCode == http.StatusOK {
+			successTimes = append(successTimes, r.At)
+		}
+	}
+	if len(successTimes) == 0 {
+		fmt.Println("Time to sellout: no successful reservation")
+		return
+	}
+
+	slices.SortFunc(successTimes, func(a, b time.Time) int { return a.Compare(b) })
+	first, last := successTimes[0], successTimes[len(successTimes)-1]
+	fmt.Printf("Time to sellout: %v (%d reservations)\n", last.Sub(first), len(successTimes))
+
+	buckets := make([]int, int(last.Sub(first)/time.Second)+1)
+	for _, t := range successTimes {
+		buckets[int(t.Sub(first)/time.Second)]++
+	}
+	fmt.Println("Reservations per second:")
+	for i, n := range buckets {
+		fmt.Printf("  %4ds: %d\n", i, n)
+	}
+}
+
+// RTT 통계 (히스토그램, 백분위, 느린 요청) 에 넣는 결과
+// 응답을 받은 요청만, 시간 초과 후 좌석 조회로 확인한 성공은 Duration 이 시간 초과까지라 뺀다
+func hasRTT(r Result) bool {
+	return r.Duration > 0 && !r.Verified
+}
+
+// RTT 히스토그램 구간 (상한, 마지막은 무제한)
+var histogramBuckets = []struct {
+	label string
+	upper time.Duration
+}{
+	{"0-10ms", 10 * time.Millisecond},
+	{"10-50ms", 50 * time.Millisecond},
+	{"50-100ms", 100 * time.Millisecond},
+	{"100-500ms", 500 * time.Millisecond},
+	{"500ms+", 0},
+}
+
+const histogramBarWidth = 50
+
+// 응답을 받은 요청들의 RTT 분포를 텍스트 막대로 출력
+func printLatencyHistogram(results []Result) {
+	counts := make([]int, len(histogramBuckets))
+	total := 0
+	for _, r := range results {
+		if !hasRTT(r) {
+			continue
+		}
+		total++
+		for i, b := range histogramBuckets {
+			if b.upper == 0 || r.Duration < b.upper {
+				counts[i]++
+				break
+			}
+		}
+	}
+
+	fmt.Println("RTT histogram:")
+	peak := slices.Max(counts)
+	for i, b := range histogramBuckets {
+		bar := 0
+		if peak > 0 {
+			bar = counts[i] * histogramBarWidth / peak
+		}
+		pct := 0.0
+		if total > 0 {
+			pct = float64(counts[i]) * 100 / float64(total)
+		}
+		fmt.Printf("  %-10s %7d (%5.1f%%) %s\n", b.label, counts[i], pct, strings.Repeat("#", bar))
+	}
+}
+
+// 가장 느린 요청 n 개를 성공, 실패로 나눠 출력 (-slowest, 0 이면 생략)
+// 응답을 받은 요청만 대상으로, 평균에 묻히는 잠금 대기나 GC 멈춤 같은 이상치를 찾는 용도
+func printSlowest(results []Result, n int) {
+	if n <= 0 {
+		return
+	}
+	var ok, failed []Result
+	for _, r := range results {
+		if !hasRTT(r) {
+			continue
+		}
+		if r.StatusCode == http.StatusOK {
+			ok = append(ok, r)
+		} else {
+			failed = append(failed, r)
+		}
+	}
+
+	for _, group := range []struct {
+		label   string
+		results []Result
+	}{{"successful", ok}, {"failed", failed}} {
+		slices.SortFunc(group.results, func(a, b Result) int { return cmp.Compare(b.Duration, a.Duration) })
+		fmt.Printf("Slowest %s requests:\n", group.label)
+		for _, r := range group.results[:min(n, len(group.results))] {
+			fmt.Printf("  %-10v user=%d seat=%d status=%d\n", r.Duration, r.UserID, r.SeatID, r.StatusCode)
+		}
+	}
+}
+
+// 응답을 받은 요청의 RTT 백분위 (nearest-rank), 응답이 하나도 없으면 ok 는 false
+func latencyPercentile(results []Result, p float64) (time.Duration, bool) {
+	var rtts []time.Duration
+	for _, r := range results {
+		if hasRTT(r) {
+			rtts = append(rtts, r.Duration)
+		}
+	}
+	if len(rtts) == 0 {
+		return 0, false
+	}
+	slices.Sort(rtts)
+	return rtts[percentileIndex(len(rtts), p)], true
+}
+
+// 정렬된 n 개 값에서 p 백분위 위치 (nearest-rank, ceil(n*p/100) 번째)
+func percentileIndex(n int, p float64) int {
+	i := int(math.Ceil(float64(n)*p/100)) - 1
+	return min(max(i, 0), n-1)
+}
+
+// RTT p50, p90, p99 출력
+func printLatencyPercentiles(results []Result) {
+	fmt.Print("RTT percentiles:")
+	for _, p := range []float64{50, 90, 99} {
+		d, ok := latencyPercentile(results, p)
+		if !ok {
+			fmt.Println(" no responses")
+			return
+		}
+		fmt.Printf(" p%v=%v", p, d)
+	}
+	fmt.Println()
+}
@@ -0,0 +1,25 @@
+//go:build unix
+
+package main
+
+import "syscall"
+
+// 열 수 있는 파일 디스크립터 수 (RLIMIT_NOFILE) 를 want 까지 올려 보고 적용된 soft limit 반환
+// hard limit 을 넘을 수는 없다
+func raiseFileLimit(want uint64) (uint64, error) {
+	var lim syscall.Rlimit
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return 0, err
+	}
+	if lim.Cur >= want {
+		return lim.Cur, nil
+	}
+	lim.Cur = min(want, lim.Max)
+	if err := syscall.Setrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return 0, err
+	}
+	if err := syscall.Getrlimit(syscall.RLIMIT_NOFILE, &lim); err != nil {
+		return 0, err
+	}
+	return lim.Cur, nil
+}
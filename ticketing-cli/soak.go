@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"runtime"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// 예매와 취소를 반복하는 장시간 부하 설정
+type Soak struct {
+	Duration time.Duration // 전체 실행 시간
+	Hold     time.Duration // 예매 후 취소까지 좌석을 잡고 있는 시간
+	Report   time.Duration // 중간 집계 출력 간격
+}
+
+// soak 실행 중 누적 집계
+type soakStats struct {
+	reserved    atomic.Int64 // 예매 성공
+	conflicts   atomic.Int64 // 409
+	cancelled   atomic.Int64 // 취소 성공
+	cancelFails atomic.Int64 // 자기 좌석 취소 실패 (누수나 정합성 문제)
+	errors      atomic.Int64 // 응답 없음이나 그 밖의 상태 코드
+}
+
+func (s *soakStats) String() string {
+	return fmt.Sprintf("reserved=%d cancelled=%d conflicts=%d cancel_failures=%d errors=%d",
+		s.reserved.Load(), s.cancelled.Load(), s.conflicts.Load(), s.cancelFails.Load(), s.errors.Load())
+}
+
+// 본인 좌석 예매 취소 요청, 응답 상태 코드를 돌려준다
+func cancelReservation(ctx context.Context, client *http.Client, req ReserveRequest) (int, error) {
+	body, _ := json.Marshal(req)
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, cancelURL, bytes.NewBuffer(body))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// ctx 가 끝날 때까지 빈 좌석 하나를 예매하고 잠시 뒤 취소하기를 반복
+// 결과를 쌓아 두지 않고 집계만 하므로 오래 돌려도 클라이언트 메모리가 늘지 않는다
+func soakClient(ctx context.Context, userID int, client *http.Client, rng *rand.Rand, hold time.Duration, stats *soakStats, wg *sync.WaitGroup) {
+	defer wg.Done()
+
+	for ctx.Err() == nil {
+		seats, err := fetchAvailableSeats(ctx, client)
+		if err != nil || len(seats) == 0 {
+			sleepCtx(ctx, time.Duration(100+rng.IntN(100))*time.Millisecond)
+			continue
+		}
+
+		req := ReserveRequest{UserID: userID, SeatID: seats[rng.IntN(len(seats))]}
+		result := tryReserve(ctx, client, req)
+		switch {
+		case result.Err != nil:
+			if ctx.Err() == nil {
+				stats.errors.Add(1)
+			}
+		case result.StatusCode == http.StatusOK:
+			stats.reserved.Add(1)
+			sleepCtx(ctx, hold)
+			// 실행이 끝나도 잡은 좌석은 풀어 두도록 취소는 ctx 취소와 무관하게 보낸다
+			code, err := cancelReservation(context.WithoutCancel(ctx), client, req)
+			if err == nil && code == http.StatusOK {
+				stats.cancelled.Add(1)
+			} else {
+				stats.cancelFails.Add(1)
+			}
+			continue
+		case result.StatusCode == http.StatusConflict:
+			stats.conflicts.Add(1)
+		default:
+			stats.errors.Add(1)
+		}
+
+		if result.RetryAfter > 0 {
+			sleepCtx(ctx, result.RetryAfter)
+		} else if d := backoff.delay(rng, 1); d > 0 {
+			sleepCtx(ctx, d)
+		}
+	}
+}
+
+// soak 클라이언트를 띄우고 끝날 때까지 주기적으로 집계와 클라이언트 자원 사용량 출력
+// 취소에 실패한 좌석이 없으면 true
+func runSoak(ctx context.Context, s Soak, clients int, seed uint64, clientFor func(i int) *http.Client, userIDFor func(i int) int) bool {
+	ctx, cancel := context.WithTimeout(ctx, s.Duration)
+	defer cancel()
+
+	var (
+		stats soakStats
+		wg    sync.WaitGroup
+	)
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		userID := userIDFor(i)
+		go soakClient(ctx, userID, clientFor(i), newClientRand(seed, userID), s.Hold, &stats, &wg)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	start := time.Now()
+	ticker := time.NewTicker(s.Report)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			var m runtime.MemStats
+			runtime.ReadMemStats(&m)
+			fmt.Printf("soak %v: %s goroutines=%d heap=%dKiB\n",
+				time.Since(start).Truncate(time.Second), &stats, runtime.NumGoroutine(), m.HeapAlloc/1024)
+		case <-done:
+			fmt.Printf("Soak finished after %v: %s\n", time.Since(start).Truncate(time.Second), &stats)
+			if n := stats.cancelFails.Load(); n > 0 {
+				fmt.Printf("❌ %d reserved seats could not be cancelled\n", n)
+				return false
+			}
+			return true
+		}
+	}
+}
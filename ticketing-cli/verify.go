@@ -0,0 +1,162 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+)
+
+// 서버의 예매 내역 한 건 (/admin/reservations 응답)
+type ServerReservation struct {
+	SeatID int `json:"seat_id"`
+	UserID int `json:"user_id"`
+}
+
+const adminPageSize = 1000
+
+// 서버에 기록된 좌석별 예매자 조회 (관리자 API 페이지 순회)
+func fetchServerReservations(client *http.Client, adminToken string) (map[int]int, error) {
+	owners := make(map[int]int)
+	for offset := 0; ; offset += adminPageSize {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?limit=%d&offset=%d", adminListURL, adminPageSize, offset), nil)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("X-Admin-Token", adminToken)
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		var page []ServerReservation
+		if resp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+		} else {
+			err = json.NewDecoder(resp.Body).Decode(&page)
+		}
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+
+		for _, r := range page {
+			owners[r.SeatID] = r.UserID
+		}
+		if len(page) < adminPageSize {
+			return owners, nil
+		}
+	}
+}
+
+// 한 좌석이 서로 다른 사용자에게 중복 예매되었는지 검사
+// 클라이언트가 받은 200 응답끼리 비교하고, 관리자 토큰이 있으면 서버 기록과도 대조한다
+func checkDuplicateReservations(client *http.Client, adminToken string, results []Result) {
+	winners := make(map[int][]int) // seat_id -> 200 을 받은 user_id 목록
+	for _, r := range results {
+		if r.StatusCode == http.StatusOK && !slices.Contains(winners[r.SeatID], r.UserID) {
+			winners[r.SeatID] = append(winners[r.SeatID], r.UserID)
+		}
+	}
+
+	duplicates := 0
+	for seatID, users := range winners {
+		if len(users) > 1 {
+			duplicates++
+			fmt.Printf("❌ Double booking: seat %d confirmed to users %v\n", seatID, users)
+		}
+	}
+
+	mismatches := 0
+	if adminToken != "" {
+		owners, err := fetchServerReservations(client, adminToken)
+		if err != nil {
+			fmt.Printf("Reservation cross-check skipped: %v\n", err)
+		} else {
+			for seatID, users := range winners {
+				if owner, ok := owners[seatID]; !ok || !slices.Contains(users, owner) {
+					mismatches++
+					fmt.Printf("❌ Owner mismatch: seat %d confirmed to %v but server records user %d\n", seatID, users, owner)
+				}
+			}
+		}
+	}
+
+	if duplicates == 0 && mismatches == 0 {
+		fmt.Println("✅ No duplicate reservations detected")
+	}
+}
+
+// 서버의 좌석 상태 집계 (/seats/count 응답)
+type SeatCounts struct {
+	Total     int `json:"total"`
+	Available int `json:"available"`
+	Reserved  int `json:"reserved"`
+	Disabled  int `json:"disabled"`
+}
+
+// 종료 후 재고 정합성 검사
+// 상태별 합계가 전체 좌석 수와 같아야 하고, 매진까지 돌렸다면 남은 좌석이 없어야 한다
+func checkInventory(client *http.Client, expectSoldOut bool) bool {
+	resp, err := client.Get(seatCountURL)
+	if err != nil {
+		fmt.Printf("❌ Inventory check failed: %v\n", err)
+		return false
+	}
+	defer resp.Body.Close()
+
+	var c SeatCounts
+	if resp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("unexpected status %d", resp.StatusCode)
+	} else {
+		err = json.NewDecoder(resp.Body).Decode(&c)
+	}
+	if err != nil {
+		fmt.Printf("❌ Inventory check failed: %v\n", err)
+		return false
+	}
+
+	ok := true
+	if c.Reserved+c.Available+c.Disabled != c.Total {
+		ok = false
+		fmt.Printf("❌ Inventory mismatch: reserved %d + available %d + disabled %d != total %d\n", c.Reserved, c.Available, c.Disabled, c.Total)
+	}
+	if expectSoldOut && c.Available != 0 {
+		ok = false
+		fmt.Printf("❌ Inventory not exhausted: %d seats still available\n", c.Available)
+	}
+	if ok {
+		fmt.Printf("✅ Inventory consistent: %d reserved, %d disabled, %d total\n", c.Reserved, c.Disabled, c.Total)
+	}
+	return ok
+}
+
+// 좌석 한 개의 현재 예매자 조회 (예매되지 않았으면 0)
+func fetchSeatOwner(client *http.Client, adminToken string, seatID int) (int, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s?seat_id=%d", adminSeatURL, seatID), nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("X-Admin-Token", adminToken)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	var seat struct {
+		Status string `json:"status"`
+		UserID int    `json:"user_id"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&seat); err != nil {
+		return 0, err
+	}
+	if seat.Status != "reserved" {
+		return 0, nil
+	}
+	return seat.UserID, nil
+}